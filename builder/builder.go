@@ -1,35 +1,51 @@
 package builder
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/codegangsta/cli"
 	"github.com/siscia/effe-tool/commons"
+	"github.com/siscia/effe-tool/naming"
 	"github.com/siscia/effe-tool/sources"
 	"os"
 	"os/exec"
 	"path/filepath"
 )
 
-func createFilenameExecutable(name, version string) string {
-	return name + "_v" + version
+// CompileSingleFile compile an effe to a single binary
+// using the runtime core.
+// It returns the path where the executable is been created
+func CompileSingleFile(sourcePath string, opts Options) (string, error) {
+	return CompileWithCore(sourcePath, sources.Core, opts)
 }
 
-// compileSingleFile compile an effe to a single binary.
-// It start by creating a temporany directory where it moves
+// CompileWithCore compile an effe to a single binary using
+// `core` as main package.
+// It start by creating a workspace where it moves
 // the logic of the effe, and the core.
-// Then it adds the temporany dir just created to the GOPATH
+// Then it adds the workspace just created to the GOPATH
 // Finally it invoke the go tool to actually compile the file,
 // it redirects the Stdout and the Stderr so that the user can
 // actually see compilation errors.
-// It returns the path where the executable is been created
-func compileSingleFile(sourcePath string, cgoEnabled bool) (string, error) {
+// The flags of the go tool come from the build profile and
+// the other options in `opts`.
+// It returns the path where the executable is been created,
+// inside the workspace: the caller must close the workspace once
+// done with the executable.
+// If the compilation fails the workspace is closed.
+func CompileWithCore(sourcePath, core string, opts Options) (execPath string, err error) {
 
-	// Creating temporany directory and structure
-	dir := os.TempDir() + "/effebuild-" + commons.RandomSuffix()
-	if err := os.Mkdir(dir, 0777); err != nil {
+	// Creating the workspace and structure
+	dir, err := commons.NewWorkspace(commons.BuildWorkspace)
+	if err != nil {
 		fmt.Println(err)
 		return "", err
 	}
+	defer func() {
+		if err != nil {
+			commons.CloseWorkspace(dir, true)
+		}
+	}()
 
 	dirEffe := dir + "/src/github.com/siscia/effe"
 	if err := os.MkdirAll(dirEffe, 0777); err != nil {
@@ -42,31 +58,69 @@ func compileSingleFile(sourcePath string, cgoEnabled bool) (string, error) {
 		return "", err
 	}
 
-	if err := os.Link(sourcePath, dirEffe+"/logic/logic.go"); err != nil {
+	if err := commons.StageFile(sourcePath, dirEffe+"/logic/logic.go"); err != nil {
 		fmt.Println(err)
 		return "", err
 	}
 
-	if err := commons.NewFile(dirEffe+"/effe.go", sources.Core); err != nil {
+	if err := commons.NewFile(dirEffe+"/effe.go", core); err != nil {
 		fmt.Println("Impossible to create file, exit.")
 		fmt.Println(err)
 		return "", err
 	}
 
-	// adding temporany dir to the GOPATH
-	gopath := os.Getenv("GOPATH")
-	os.Setenv("GOPATH", dir+":"+gopath)
-	defer os.Setenv("GOPATH", gopath)
+	// the environment of the go tool: the workspace is a GOPATH, not
+	// a module, added in front of the GOPATH, and the build cache is
+	// the one of effe-tool; it is set on the command only, so that
+	// nothing leaks into the next builds
+	env := append(os.Environ(),
+		"GOPATH="+dir+":"+os.Getenv("GOPATH"),
+		"GO111MODULE=off",
+		"GOCACHE="+commons.GoCacheDir(),
+	)
+	if opts.cgoEnabled() {
+		env = append(env, "CGO_ENABLED=1")
+	} else {
+		env = append(env, "CGO_ENABLED=0")
+	}
+	for name, value := range map[string]string{"GOOS": opts.GOOS, "GOARCH": opts.GOARCH, "GOARM": opts.GOARM} {
+		if value != "" {
+			env = append(env, name+"="+value)
+		}
+	}
 
-	if cgoEnabled == false {
-		cgoEnabled := os.Getenv("CGO_ENABLED")
-		os.Setenv("CGO_ENABLED", "0")
-		defer os.Setenv("CGO_ENABLED", cgoEnabled)
+	// the version of the core is printed by `-core-version`, the
+	// provenance is read back by `inspect`
+	sum, err := commons.FileSHA256(sourcePath)
+	if err != nil {
+		fmt.Println(err)
+		return "", err
 	}
+	provenance, err := json.Marshal(commons.Provenance{
+		Source:   sum,
+		Revision: commons.GitRevision(filepath.Dir(sourcePath)),
+		Core:     commons.CoreVersion(core),
+	})
+	if err != nil {
+		fmt.Println(err)
+		return "", err
+	}
+	vars := map[string]string{
+		commons.CoreVersionVar: commons.CoreVersion(core),
+		commons.ProvenanceVar:  commons.ProvenanceMarker + string(provenance),
+	}
+	for name, value := range opts.Vars {
+		vars[name] = value
+	}
+	opts.Vars = vars
 
 	// actually compile
-	cmd := exec.Command("go", "build", "-a", "-ldflags", "'-s'", "-o", dir+"/out", "-buildmode=exe", dirEffe+"/effe.go")
-
+	args := []string{"build", "-o", dir + "/out", "-buildmode=exe"}
+	args = append(args, opts.buildArgs()...)
+	args = append(args, dirEffe+"/effe.go")
+	cmd := exec.Command("go", args...)
+	// the last value of a variable wins
+	cmd.Env = env
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -82,8 +136,22 @@ func compileSingleFile(sourcePath string, cgoEnabled bool) (string, error) {
 	return dir + "/out", nil
 }
 
+// moveFile moves the executable from the workspace, when the
+// workspace is on another filesystem the executable is staged and
+// then removed.
+func moveFile(from, to string) error {
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+	os.Remove(to)
+	if err := commons.StageFile(from, to); err != nil {
+		return err
+	}
+	return os.Remove(from)
+}
+
 // compileFile is the entry point to compile an effe source.
-// The actual compilation is done by `compileSingleFile` but
+// The actual compilation is done by `CompileSingleFile` but
 // `compileFile` takes care of move the binary where the user
 // is expecting.
 // It first compile the file (passed as path).
@@ -96,61 +164,59 @@ func compileSingleFile(sourcePath string, cgoEnabled bool) (string, error) {
 // it has a default value set on the flag to `out`.
 // `execName` is the name of the executable, if not given
 // `compileFile` try to use the effe convetion to provide a name.
-func compileFile(path, dirName, execName string, cgoEnabled bool) error {
+func compileFile(path, dirName, execName string, c *cli.Context) (err error) {
+	opts, err := LoadOptions(path, c)
+	if err != nil {
+		fmt.Println("File: " + path + " | Impossible to load the build options.")
+		return err
+	}
+
 	// Actually compiling
-	tmpExecPath, err := compileSingleFile(path, cgoEnabled)
+	tmpExecPath, err := CompileSingleFile(path, opts)
 	if err != nil {
 		fmt.Println("File: " + path + " | Impossible to compile.")
 		return err
 	}
+	defer func() {
+		commons.CloseWorkspace(filepath.Dir(tmpExecPath), err != nil)
+	}()
 
 	// Gathering information
-	execVersion := ""
 	if execName == "" {
 
-		// the user want didn't provide a name for the executable
-		// we need to come out with a name
-
-		execName, execVersion, err = commons.GetNameVersion(tmpExecPath)
-		if err == nil {
-
-			// everything went well and the names comes from the info variable
-
-			execName = createFilenameExecutable(execName, execVersion)
-		} else {
-
-			// the info variable doesn't provide the right information
-			// we will use the hash of the executable as name
-
-			fmt.Println("File: " + path + " | Error in the executable info.")
-			fmt.Println("File: " + path + " | Falling back to use the hash as name.")
-			hashName, err := commons.ExecutableHash(tmpExecPath)
-			if err != nil {
+		// the user didn't provide a name for the executable,
+		// it comes from the info variable
 
-				// problem generating the hash, we don't move the executable
-
-				fmt.Println("File: " + path + " | Error in generating the hash name.")
-				fmt.Println("File: " + path + " | Actual path is: " + tmpExecPath)
-				return err
-			}
-			execName = hashName
+		log := func(msg string) {
+			fmt.Println("File: " + path + " | " + msg)
+		}
+		// a broken info variable is handled by naming.Effe
+		info, _ := commons.ExecutableInfo(tmpExecPath)
+		name, version, err := naming.Effe(info, tmpExecPath, log)
+		if err != nil {
+			log("Error in the executable info.")
+			return err
+		}
+		if execName, err = naming.FileName(name, version, log); err != nil {
+			log(err.Error())
+			return err
 		}
 	}
 
 	// Moving the file
 	totalPath, err := filepath.Abs(dirName + `/` + execName)
 	if err != nil {
-		fmt.Println("File: " + path + " | Error in getting the absolute path.\nActual path is: " + tmpExecPath)
+		fmt.Println("File: " + path + " | Error in getting the absolute path.")
 		return err
 	}
 	if err := os.MkdirAll(filepath.Dir(totalPath), 0777); err != nil {
 		fmt.Println("File: " + path + " | Impossible to create the directory: " + filepath.Dir(totalPath))
-		fmt.Println("Actual path is: " + tmpExecPath)
 		return err
 	}
-	if err := os.Rename(tmpExecPath, totalPath); err != nil {
+	if err := moveFile(tmpExecPath, totalPath); err != nil {
 		fmt.Println(err)
-		fmt.Println("File: " + path + " | Impossible to move the executable.\nActual path is: " + tmpExecPath)
+		fmt.Println("File: " + path + " | Impossible to move the executable.")
+		return err
 	}
 	fmt.Println("File: " + path + " | Everything went good, the file is been compiled.\nExecutable path: " + totalPath)
 	return nil
@@ -162,8 +228,16 @@ func compileFile(path, dirName, execName string, cgoEnabled bool) error {
 // walkAndCompile simply does nothing to the directory.
 // walkAndCompile preserve the shape of the source dir
 // into the executable directory.
+// Files and directories listed in .effeignore are skipped.
 func compileDirectory(originalPath string, c *cli.Context) {
+	ignore := commons.LoadIgnore(originalPath)
 	walkAndCompile := func(path string, f os.FileInfo, _ error) error {
+		if ignore.Match(path) {
+			if f.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 		if f.IsDir() {
 			return nil
 		}
@@ -175,7 +249,7 @@ func compileDirectory(originalPath string, c *cli.Context) {
 				return nil
 			}
 			execLocation := filepath.Dir(relativePath)
-			err = compileFile(path, c.String("dirout")+"/"+execLocation, "", c.Bool("cgo"))
+			err = compileFile(path, outputDir(originalPath, c)+"/"+execLocation, "", c)
 			if err != nil {
 				fmt.Println(err)
 			}
@@ -185,9 +259,33 @@ func compileDirectory(originalPath string, c *cli.Context) {
 	filepath.Walk(originalPath, walkAndCompile)
 }
 
+// outputDir returns the directory of the executables of `path`: the
+// one passed with --dirout, otherwise the one of its project,
+// otherwise the default of --dirout.
+func outputDir(path string, c *cli.Context) string {
+	if !c.IsSet("dirout") {
+		if dirs, err := commons.FindProjectDirs(path); err == nil && dirs.Out != "" {
+			return dirs.Out
+		}
+	}
+	return c.String("dirout")
+}
+
+// SourcesPath returns the argument of a command, or the sources of
+// the project of the current directory when there is no argument.
+func SourcesPath(c *cli.Context) string {
+	path := c.Args().First()
+	if path == "" {
+		if dirs, err := commons.FindProjectDirs("."); err == nil {
+			return dirs.Sources
+		}
+	}
+	return path
+}
+
 // Compile is the main entry point
 func Compile(c *cli.Context) {
-	path := c.Args().First()
+	path := SourcesPath(c)
 	f, err := os.Lstat(path)
 	if err != nil {
 		fmt.Println("Impossible to open the file, are you sure it exist ?")
@@ -197,7 +295,7 @@ func Compile(c *cli.Context) {
 		compileDirectory(path, c)
 	}
 	if f.Mode().IsRegular() {
-		err := compileFile(path, c.String("dirout"), c.String("out"), c.Bool("cgo"))
+		err := compileFile(path, outputDir(path, c), c.String("out"), c)
 		if err != nil {
 			fmt.Println(err)
 		}
@@ -0,0 +1,95 @@
+package builder
+
+import (
+	"github.com/siscia/effe-tool/commons"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const helloEffe = `package logic
+
+import (
+	"io"
+	"net/http"
+)
+
+var Info = ` + "`" + `{"name": "hello", "version": "1.0"}` + "`" + `
+
+type Context struct{}
+
+func Init() {}
+
+func Start() (Context, error) { return Context{}, nil }
+
+func Run(ctx Context, err error, w http.ResponseWriter, r *http.Request) error {
+	io.WriteString(w, "Hello")
+	return nil
+}
+
+func Stop(ctx Context) {}
+`
+
+// freePort returns a port of the local host nobody listens on.
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// TestReadOnlyRoot starts an effe with the root filesystem mounted
+// read-only, as in `docker run --read-only`, and checks it serves.
+func TestReadOnlyRoot(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go tool is needed to compile the effe")
+	}
+	readOnly := []string{"-rm", "sh", "-c", `mount -o remount,bind,ro / && exec "$@"`, "sh"}
+	if out, err := exec.Command("unshare", append(readOnly, "true")...).CombinedOutput(); err != nil {
+		t.Skip("no read-only root without unshare: " + string(out))
+	}
+
+	source := filepath.Join(t.TempDir(), "hello.go")
+	if err := ioutil.WriteFile(source, []byte(helloEffe), 0644); err != nil {
+		t.Fatal(err)
+	}
+	execPath, err := CompileSingleFile(source, Options{Profile: DefaultProfile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer commons.CloseWorkspace(filepath.Dir(execPath), false)
+
+	port := strconv.Itoa(freePort(t))
+	effe := exec.Command("unshare", append(readOnly, execPath, "-port", port)...)
+	if err := effe.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer effe.Process.Kill()
+	exited := make(chan error, 1)
+	go func() { exited <- effe.Wait() }()
+
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		select {
+		case err := <-exited:
+			t.Fatalf("the effe exited with a read-only root: %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+		resp, err := http.Get("http://127.0.0.1:" + port + "/_effe/health")
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("health: %s", resp.Status)
+		}
+		return
+	}
+	t.Fatal("the effe didn't serve with a read-only root")
+}
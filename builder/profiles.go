@@ -0,0 +1,194 @@
+package builder
+
+import (
+	"errors"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/commons"
+	"sort"
+	"strings"
+)
+
+// profile is a named set of flags for the go tool.
+type profile struct {
+	args    []string
+	ldflags string
+	gcflags string
+	cgo     bool
+}
+
+// DefaultProfile is used when neither the flags nor effe.json
+// select a profile.
+const DefaultProfile = "release"
+
+var profiles = map[string]profile{
+	// small executables that do not depend on the paths of the build,
+	// the build id would, since the workspace changes at every build
+	"release": {args: []string{"-trimpath"}, ldflags: "-s -w -buildid="},
+	// optimizations and inlining disabled, symbols kept for delve
+	"debug": {gcflags: "all=-N -l"},
+	// the race detector needs cgo
+	"race": {args: []string{"-race"}, cgo: true},
+}
+
+// Options are the options to compile an effe.
+type Options struct {
+	Profile string
+	Cgo     bool
+	Tags    []string
+	Ldflags string
+	Gcflags string
+	// Vars are the string variables set with -X, by import path
+	// and name
+	Vars map[string]string
+	// GOOS, GOARCH and GOARM are the target platform, empty for
+	// the host
+	GOOS   string
+	GOARCH string
+	GOARM  string
+}
+
+// BuildFlags are the flags of the commands that compile effes.
+var BuildFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "profile",
+		Value: "",
+		Usage: "Build profile: release, debug or race, default to the one of effe.json or to release.",
+	},
+	cli.BoolFlag{
+		Name:  "cgo",
+		Usage: "Set to true to enable cgo.",
+	},
+	cli.StringFlag{
+		Name:  "tags",
+		Value: "",
+		Usage: "Comma separated build tags.",
+	},
+	cli.StringFlag{
+		Name:  "ldflags",
+		Value: "",
+		Usage: "Flags passed to the linker, after the ones of the profile.",
+	},
+	cli.StringFlag{
+		Name:  "gcflags",
+		Value: "",
+		Usage: "Flags passed to the compiler, after the ones of the profile.",
+	},
+	cli.StringSliceFlag{
+		Name:  "X",
+		Value: &cli.StringSlice{},
+		Usage: "Set a string variable as `importpath.name=value`, it can be repeated.",
+	},
+}
+
+// merge overrides the options with the ones set in `config`.
+func (o *Options) merge(config commons.BuildConfig) {
+	if config.Profile != "" {
+		o.Profile = config.Profile
+	}
+	o.Cgo = o.Cgo || config.Cgo
+	if len(config.Tags) > 0 {
+		o.Tags = config.Tags
+	}
+	if config.Ldflags != "" {
+		o.Ldflags = config.Ldflags
+	}
+	if config.Gcflags != "" {
+		o.Gcflags = config.Gcflags
+	}
+	for name, value := range config.Vars {
+		o.Vars[name] = value
+	}
+}
+
+// LoadOptions returns the options to compile the effe at
+// `sourcePath`: the ones of the project in effe.json, overridden by
+// the ones of the effe in effe.json, overridden by the flags.
+func LoadOptions(sourcePath string, c *cli.Context) (Options, error) {
+	opts := Options{Profile: DefaultProfile, Vars: map[string]string{}}
+	if _, m, err := commons.FindManifest(sourcePath); err == nil {
+		opts.merge(m.Build)
+		if info, err := commons.SourceInfo(sourcePath); err == nil {
+			if effe, ok := m.Effes[info.Name]; ok {
+				opts.merge(effe.Build)
+			}
+		}
+	}
+
+	flags := commons.BuildConfig{
+		Profile: c.String("profile"),
+		Cgo:     c.Bool("cgo"),
+		Ldflags: c.String("ldflags"),
+		Gcflags: c.String("gcflags"),
+		Vars:    map[string]string{},
+	}
+	if c.String("tags") != "" {
+		flags.Tags = strings.Split(c.String("tags"), ",")
+	}
+	for _, v := range c.StringSlice("X") {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			return opts, errors.New("Wrong -X " + v + ", it must be importpath.name=value")
+		}
+		flags.Vars[parts[0]] = parts[1]
+	}
+	opts.merge(flags)
+
+	if _, ok := profiles[opts.Profile]; !ok {
+		return opts, errors.New("Unknown build profile: " + opts.Profile)
+	}
+	return opts, nil
+}
+
+// Static sets the options to build a static linux executable, the
+// kind that images need, whatever the host; it fails for the
+// profiles that need cgo.
+func (o *Options) Static() error {
+	if profiles[o.Profile].cgo {
+		return errors.New("The " + o.Profile + " profile needs cgo, images need static executables")
+	}
+	o.GOOS = "linux"
+	o.Cgo = false
+	return nil
+}
+
+// cgoEnabled tells if the effe is compiled with cgo.
+func (o Options) cgoEnabled() bool {
+	return o.Cgo || profiles[o.Profile].cgo
+}
+
+// buildArgs returns the flags for `go build` that come from the
+// profile and from the options.
+func (o Options) buildArgs() []string {
+	p := profiles[o.Profile]
+	args := append([]string{}, p.args...)
+	if len(o.Tags) > 0 {
+		args = append(args, "-tags", strings.Join(o.Tags, ","))
+	}
+	// with the same package pattern the last -gcflags wins
+	if p.gcflags != "" {
+		args = append(args, "-gcflags", p.gcflags)
+	}
+	if o.Gcflags != "" {
+		args = append(args, "-gcflags", o.Gcflags)
+	}
+	ldflags := []string{}
+	if p.ldflags != "" {
+		ldflags = append(ldflags, p.ldflags)
+	}
+	if o.Ldflags != "" {
+		ldflags = append(ldflags, o.Ldflags)
+	}
+	names := make([]string, 0, len(o.Vars))
+	for name := range o.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		// quoted since the value may contain spaces
+		ldflags = append(ldflags, "-X '"+name+"="+o.Vars[name]+"'")
+	}
+	if len(ldflags) > 0 {
+		args = append(args, "-ldflags", strings.Join(ldflags, " "))
+	}
+	return args
+}
@@ -0,0 +1,106 @@
+package clean
+
+import (
+	"fmt"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/commons"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func logError(path, msg string) {
+	fmt.Println("File: " + path + " | " + msg)
+}
+
+func remove(path string) {
+	if err := os.RemoveAll(path); err != nil {
+		logError(path, "Impossible to remove.")
+		fmt.Println(err)
+		return
+	}
+	logError(path, "Removed.")
+}
+
+// isWorkspace tells if `name` is the name of a workspace.
+func isWorkspace(name string) bool {
+	for _, prefix := range commons.WorkspacePrefixes {
+		if strings.HasPrefix(name, prefix+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanWorkspaces removes the workspaces not modified since
+// `olderThan`, the younger ones may belong to a running build.
+func cleanWorkspaces(olderThan time.Duration) {
+	root := commons.WorkRoot()
+	entries, err := ioutil.ReadDir(root)
+	if err != nil {
+		logError(root, "Impossible to read the work root.")
+		fmt.Println(err)
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && isWorkspace(entry.Name()) && time.Since(entry.ModTime()) >= olderThan {
+			remove(filepath.Join(root, entry.Name()))
+		}
+	}
+}
+
+// outputDir returns the directory of the executables: the one passed
+// with --dirout, otherwise the one of the project of the current
+// directory.
+// Without both it returns "", a directory that only looks like the
+// output, as ./out, is never removed.
+func outputDir(c *cli.Context) string {
+	if c.String("dirout") != "" {
+		return c.String("dirout")
+	}
+	if dirs, err := commons.FindProjectDirs("."); err == nil {
+		return dirs.Out
+	}
+	return ""
+}
+
+// Clean removes the stale workspaces, the directory of the executables
+// and, with --cache, the cache of effe-tool.
+func Clean(c *cli.Context) {
+	cleanWorkspaces(c.Duration("older-than"))
+
+	if c.Bool("cache") {
+		if _, err := os.Stat(commons.CacheDir()); err == nil {
+			remove(commons.CacheDir())
+		}
+	}
+
+	if c.Bool("keep-out") {
+		return
+	}
+	out := outputDir(c)
+	if out == "" {
+		return
+	}
+	absOut, err := filepath.Abs(out)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// refusing to remove the current directory or one of its parents
+	sep := string(filepath.Separator)
+	if strings.HasPrefix(cwd+sep, strings.TrimSuffix(absOut, sep)+sep) {
+		logError(out, "Not removed, it contains the current directory.")
+		return
+	}
+	if _, err := os.Stat(out); err == nil {
+		remove(out)
+	}
+}
@@ -0,0 +1,60 @@
+package commons
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os/exec"
+	"strings"
+)
+
+// CoreVersionVar is the variable of the core that the builder sets
+// to the version of the core.
+const CoreVersionVar = "main.coreVersion"
+
+// ProvenanceVar is the variable of the core that the builder sets to
+// the provenance of the executable: ProvenanceMarker followed by the
+// Provenance as JSON, so that it can be found inside the executable
+// without running it, even when the symbols are stripped.
+const ProvenanceVar = "main.provenance"
+
+// ProvenanceMarker precedes the provenance inside the executables.
+const ProvenanceMarker = "effe-provenance:"
+
+// Provenance is where an executable comes from.
+type Provenance struct {
+	// Source is the sha256 of the source of the effe
+	Source string `json:"source"`
+	// Revision is the git revision of the project of the source, empty
+	// outside of a repository
+	Revision string `json:"revision,omitempty"`
+	// Core is the version of the core
+	Core string `json:"core"`
+}
+
+// GitRevision returns the git revision of the repository that
+// contains `dir`, empty outside of a repository.
+func GitRevision(dir string) string {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// CoreVersion identifies the source of a core with the first 12
+// hex digits of its sha256.
+func CoreVersion(core string) string {
+	sum := sha256.Sum256([]byte(core))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// ExecutableCoreVersion runs the executable with the `-core-version`
+// option, the executables built before the option existed return an
+// error.
+func ExecutableCoreVersion(path string) (string, error) {
+	out, err := exec.Command(path, "-core-version").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,72 @@
+package commons
+
+import (
+	"encoding/base64"
+	"reflect"
+)
+
+// Request is the envelope of a request to an effe: the one read by
+// the core in one-shot mode, sent by `invoke` and described by the
+// fixtures of `test`.
+// The body is plain text unless IsBase64Encoded is set.
+type Request struct {
+	Method          string              `json:"method"`
+	Path            string              `json:"path"`
+	Headers         map[string][]string `json:"headers"`
+	Body            string              `json:"body"`
+	IsBase64Encoded bool                `json:"isBase64Encoded"`
+}
+
+// Response is the envelope of the response of an effe, the body is
+// encoded in base64 only when it is not valid UTF-8.
+type Response struct {
+	Status          int                 `json:"status"`
+	Headers         map[string][]string `json:"headers"`
+	Body            string              `json:"body"`
+	IsBase64Encoded bool                `json:"isBase64Encoded"`
+}
+
+func decodeBody(body string, isBase64Encoded bool) ([]byte, error) {
+	if isBase64Encoded {
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return []byte(body), nil
+}
+
+// DecodedBody returns the body of the request, decoded from base64
+// if needed.
+func (r Request) DecodedBody() ([]byte, error) {
+	return decodeBody(r.Body, r.IsBase64Encoded)
+}
+
+// DecodedBody returns the body of the response, decoded from base64
+// if needed.
+func (r Response) DecodedBody() ([]byte, error) {
+	return decodeBody(r.Body, r.IsBase64Encoded)
+}
+
+// GoStruct returns the Go declaration of a struct type called `name`
+// with the fields, and the tags, of the struct `v`, the ones of its
+// embedded structs included.
+// The programs that effe-tool generates can't import commons, they
+// declare the envelopes this way.
+func GoStruct(name string, v interface{}) string {
+	var fields func(t reflect.Type) string
+	fields = func(t reflect.Type) string {
+		declaration := ""
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				declaration += fields(f.Type)
+				continue
+			}
+			declaration += "\t" + f.Name + " " + f.Type.String()
+			if f.Tag != "" {
+				declaration += " `" + string(f.Tag) + "`"
+			}
+			declaration += "\n"
+		}
+		return declaration
+	}
+	return "type " + name + " struct {\n" + fields(reflect.TypeOf(v)) + "}"
+}
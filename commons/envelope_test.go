@@ -0,0 +1,81 @@
+package commons
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGoStruct(t *testing.T) {
+	type outcome struct {
+		Response
+		Panic    string        `json:"panic,omitempty"`
+		Duration time.Duration `json:"duration"`
+		private  bool
+	}
+	want := "type outcome struct {\n" +
+		"\tStatus int `json:\"status\"`\n" +
+		"\tHeaders map[string][]string `json:\"headers\"`\n" +
+		"\tBody string `json:\"body\"`\n" +
+		"\tIsBase64Encoded bool `json:\"isBase64Encoded\"`\n" +
+		"\tPanic string `json:\"panic,omitempty\"`\n" +
+		"\tDuration time.Duration `json:\"duration\"`\n" +
+		"\tprivate bool\n" +
+		"}"
+	if got := GoStruct("outcome", outcome{}); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+// fieldsOf describes the fields of a struct as name, type and tag.
+func fieldsOf(v interface{}) []string {
+	fields := []string{}
+	typ := reflect.TypeOf(v)
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		fields = append(fields, f.Name+" "+f.Type.String()+" "+string(f.Tag))
+	}
+	return fields
+}
+
+// TestCoreEnvelopes checks that the core, which can't import commons,
+// reads and writes the same envelopes in one-shot mode.
+func TestCoreEnvelopes(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "../effe/effe.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	core := map[string][]string{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		if s, ok := spec.Type.(*ast.StructType); ok {
+			fields := []string{}
+			for _, f := range s.Fields.List {
+				tag := ""
+				if f.Tag != nil {
+					tag, _ = strconv.Unquote(f.Tag.Value)
+				}
+				for _, name := range f.Names {
+					fields = append(fields, name.Name+" "+types.ExprString(f.Type)+" "+tag)
+				}
+			}
+			core[spec.Name.Name] = fields
+		}
+		return false
+	})
+
+	for name, v := range map[string]interface{}{"invocationRequest": Request{}, "invocationResponse": Response{}} {
+		if got, want := core[name], fieldsOf(v); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s of the core:\n%s\nwant:\n%s", name, strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package commons
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"strconv"
+)
+
+// Info is the content of the Info variable of an effe.
+// Name and version are required, routes and params are optional and
+// only document the requests the effe answers.
+type Info struct {
+	Name    string  `json:"name"`
+	Version string  `json:"version"`
+	Doc     string  `json:"doc"`
+	Routes  []Route `json:"routes,omitempty"`
+	Params  []Param `json:"params,omitempty"`
+}
+
+// Route is a method and path served by the effe.
+type Route struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Doc    string `json:"doc,omitempty"`
+}
+
+// Param is a parameter read by the effe, `In` is where it is read
+// from: query, header, body or env.
+type Param struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Doc      string `json:"doc,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// ParseInfo parses the JSON content of the Info variable.
+func ParseInfo(content []byte) (Info, error) {
+	var i Info
+	err := json.Unmarshal(content, &i)
+	return i, err
+}
+
+// stringValue evaluates a string literal or a concatenation of
+// string literals.
+func stringValue(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(e.Value)
+		return s, err == nil
+	case *ast.ParenExpr:
+		return stringValue(e.X)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		x, ok := stringValue(e.X)
+		if !ok {
+			return "", false
+		}
+		y, ok := stringValue(e.Y)
+		return x + y, ok
+	}
+	return "", false
+}
+
+// SourceInfo reads the Info of the effe source at `path` without
+// compiling it.
+func SourceInfo(path string) (Info, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return Info{}, err
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range value.Names {
+				if name.Name != "Info" || i >= len(value.Values) {
+					continue
+				}
+				content, ok := stringValue(value.Values[i])
+				if !ok {
+					return Info{}, errors.New("Info is not a constant string")
+				}
+				return ParseInfo([]byte(content))
+			}
+		}
+	}
+	return Info{}, errors.New("Info is not declared")
+}
+
+// ExecutableInfo executes the binary with the `-info` option and
+// parses its output.
+func ExecutableInfo(path string) (Info, error) {
+	out, err := exec.Command(path, "-info", "True").Output()
+	if err != nil {
+		return Info{}, err
+	}
+	return ParseInfo(out)
+}
+
+// ImageInfo runs the docker image with the `-info` option, the
+// executable is the entrypoint of the images created by effe-tool.
+func ImageInfo(image string) (Info, error) {
+	out, err := exec.Command("docker", "run", "--rm", image, "-info", "True").Output()
+	if err != nil {
+		return Info{}, err
+	}
+	return ParseInfo(out)
+}
@@ -0,0 +1,192 @@
+package commons
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestFile is the name of the project manifest created by
+// `effe-tool init`.
+const ManifestFile = "effe.json"
+
+// Manifest describes an effe project, all the paths are relative
+// to the directory of the manifest.
+// Build is how the effes of the project are compiled and Docker how
+// their images are built, Effes holds the settings of single effes,
+// by name, that override the ones of the project.
+type Manifest struct {
+	Name     string                `json:"name"`
+	Sources  string                `json:"sources"`
+	Out      string                `json:"out"`
+	Fixtures string                `json:"fixtures"`
+	Build    BuildConfig           `json:"build"`
+	Docker   DockerConfig          `json:"docker"`
+	Effes    map[string]EffeConfig `json:"effes,omitempty"`
+}
+
+// BuildConfig selects the build profile and the flags passed to the
+// go tool, see the builder package.
+type BuildConfig struct {
+	Profile string            `json:"profile,omitempty"`
+	Cgo     bool              `json:"cgo,omitempty"`
+	Tags    []string          `json:"tags,omitempty"`
+	Ldflags string            `json:"ldflags,omitempty"`
+	Gcflags string            `json:"gcflags,omitempty"`
+	Vars    map[string]string `json:"vars,omitempty"`
+}
+
+// DockerConfig describes the images of the effes, see the docker
+// package.
+// Dockerfile is a template, relative to the manifest, used in
+// place of the built-in variant.
+// Engine builds the images: docker, podman, buildah or oci, empty
+// to detect it.
+// Registry and Repository prefix the names of the images, Tags are
+// the tag policies.
+// User is the user running the effe, as uid:gid.
+type DockerConfig struct {
+	Engine     string            `json:"engine,omitempty"`
+	Registry   string            `json:"registry,omitempty"`
+	Repository string            `json:"repository,omitempty"`
+	Tags       []string          `json:"tags,omitempty"`
+	Variant    string            `json:"variant,omitempty"`
+	BaseImage  string            `json:"baseImage,omitempty"`
+	Dockerfile string            `json:"dockerfile,omitempty"`
+	Port       int               `json:"port,omitempty"`
+	User       string            `json:"user,omitempty"`
+	Env        map[string]string `json:"env,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// EffeConfig are the settings of a single effe.
+type EffeConfig struct {
+	Build BuildConfig `json:"build"`
+}
+
+// LoadManifest reads the manifest inside `dir`.
+func LoadManifest(dir string) (Manifest, error) {
+	var m Manifest
+	content, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return m, err
+	}
+	err = json.Unmarshal(content, &m)
+	return m, err
+}
+
+// FindManifest looks for the manifest in the directory of `path`
+// and in its parents, it returns the directory of the manifest.
+func FindManifest(path string) (string, Manifest, error) {
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return "", Manifest{}, err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+			m, err := LoadManifest(dir)
+			return dir, m, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", Manifest{}, os.ErrNotExist
+		}
+		dir = parent
+	}
+}
+
+// ProjectDirs are the directories of a project: Sources holds the
+// effes, Out the executables and Fixtures the fixtures of the test
+// command. They are joined to the directory of the manifest, and
+// empty when the manifest does not set them.
+type ProjectDirs struct {
+	Sources  string
+	Out      string
+	Fixtures string
+}
+
+// FindProjectDirs returns the directories of the project that
+// contains `path`, a file or a directory.
+func FindProjectDirs(path string) (ProjectDirs, error) {
+	if f, err := os.Stat(path); err == nil && f.IsDir() {
+		path = filepath.Join(path, ManifestFile)
+	}
+	dir, m, err := FindManifest(path)
+	if err != nil {
+		return ProjectDirs{}, err
+	}
+	resolve := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+	return ProjectDirs{resolve(m.Sources), resolve(m.Out), resolve(m.Fixtures)}, nil
+}
+
+// IgnoreFile is the name of the file listing what effe-tool skips
+// while walking a directory.
+const IgnoreFile = ".effeignore"
+
+// Ignore holds the patterns of a .effeignore file, one pattern per
+// line in the syntax of filepath.Match, empty lines and lines
+// starting with # are skipped.
+// A pattern matches either the name of a file or its path relative
+// to the walked directory, when a directory matches everything
+// inside it is skipped.
+type Ignore struct {
+	root     string
+	patterns []string
+}
+
+// LoadIgnore reads the .effeignore file of `root`, looking first in
+// `root` and then in its parents, so that a project wide .effeignore
+// applies also when walking a subdirectory.
+// If no file is found nothing is ignored.
+func LoadIgnore(root string) *Ignore {
+	ignore := &Ignore{root: root}
+	dir, err := filepath.Abs(root)
+	if err != nil {
+		return ignore
+	}
+	for {
+		file, err := os.Open(filepath.Join(dir, IgnoreFile))
+		if err == nil {
+			defer file.Close()
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if line != "" && !strings.HasPrefix(line, "#") {
+					ignore.patterns = append(ignore.patterns, strings.TrimSuffix(line, "/"))
+				}
+			}
+			return ignore
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ignore
+		}
+		dir = parent
+	}
+}
+
+// Match tells if `path`, found walking the root, must be skipped.
+func (i *Ignore) Match(path string) bool {
+	relative, err := filepath.Rel(i.root, path)
+	if err != nil || relative == "." {
+		return false
+	}
+	relative = filepath.ToSlash(relative)
+	for _, pattern := range i.patterns {
+		if ok, _ := filepath.Match(pattern, filepath.Base(path)); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, relative); ok {
+			return true
+		}
+	}
+	return false
+}
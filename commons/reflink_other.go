@@ -0,0 +1,13 @@
+//go:build !linux
+
+package commons
+
+import (
+	"errors"
+	"os"
+)
+
+// reflink is available only on linux, elsewhere the files are copied.
+func reflink(source, dest *os.File) error {
+	return errors.New("reflink not supported")
+}
@@ -0,0 +1,82 @@
+package commons
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+)
+
+// FileSHA256 returns the hex encoded sha256 of the file at `path`.
+func FileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// StageFile makes the file `from` available at `to`, usually inside
+// a workspace.
+// It creates a hard link when possible, otherwise, as when `to` is
+// on another filesystem, it clones the file with a reflink or it
+// copies it; the mode of the file is preserved.
+// Clones and copies are verified comparing the hashes of the files,
+// a failed copy is removed so that staging the file again works.
+func StageFile(from, to string) (err error) {
+	if err := os.Link(from, to); err == nil {
+		return nil
+	}
+
+	info, err := os.Stat(from)
+	if err != nil {
+		return err
+	}
+	source, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	dest, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(to)
+		}
+	}()
+	if err := reflink(source, dest); err != nil {
+		if _, err := io.Copy(dest, source); err != nil {
+			dest.Close()
+			return err
+		}
+	}
+	// the mode passed to OpenFile is filtered by the umask
+	if err := dest.Chmod(info.Mode().Perm()); err != nil {
+		dest.Close()
+		return err
+	}
+	if err := dest.Close(); err != nil {
+		return err
+	}
+
+	expected, err := FileSHA256(from)
+	if err != nil {
+		return err
+	}
+	actual, err := FileSHA256(to)
+	if err != nil {
+		return err
+	}
+	if expected != actual {
+		return errors.New("The staged copy of " + from + " differs from the original")
+	}
+	return nil
+}
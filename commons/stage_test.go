@@ -0,0 +1,46 @@
+package commons
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStageFile(t *testing.T) {
+	dir := t.TempDir()
+	from := filepath.Join(dir, "effe")
+	if err := ioutil.WriteFile(from, []byte("an effe"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	to := filepath.Join(dir, "staged")
+	if err := StageFile(from, to); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(to)
+	if err != nil || string(content) != "an effe" {
+		t.Errorf("staged %q, %v", content, err)
+	}
+	if err := StageFile(from, to); err == nil {
+		t.Error("staged over an existing file")
+	}
+}
+
+// TestStageFileFailedCopy stages a directory, which can't be linked
+// nor read, and checks that the failed copy is removed.
+func TestStageFileFailedCopy(t *testing.T) {
+	dir := t.TempDir()
+	to := filepath.Join(dir, "staged")
+	for i := 0; i < 2; i++ {
+		err := StageFile(dir, to)
+		if err == nil {
+			t.Fatal("staged a directory")
+		}
+		if os.IsExist(err) {
+			t.Fatalf("the failed copy was left behind: %s", err)
+		}
+		if _, err := os.Lstat(to); !os.IsNotExist(err) {
+			t.Fatalf("the failed copy is still at %s", to)
+		}
+	}
+}
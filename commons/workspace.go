@@ -0,0 +1,82 @@
+package commons
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Prefixes of the workspaces, the temporary directories where
+// effe-tool builds executables and images.
+const (
+	BuildWorkspace  = "effebuild"
+	DockerWorkspace = "effedocker"
+)
+
+// WorkspacePrefixes are all the prefixes, `effe-tool clean` removes
+// the directories starting with any of them.
+var WorkspacePrefixes = []string{BuildWorkspace, DockerWorkspace}
+
+var (
+	workRoot = ""
+	keepWork = false
+)
+
+// SetWorkRoot sets the directory where the workspaces are created,
+// an empty string means the temporary directory of the system.
+func SetWorkRoot(dir string) {
+	workRoot = dir
+}
+
+// WorkRoot returns the directory where the workspaces are created.
+func WorkRoot() string {
+	if workRoot == "" {
+		return os.TempDir()
+	}
+	return workRoot
+}
+
+// SetKeepWork sets whether the workspaces of failed builds are kept
+// for inspection.
+func SetKeepWork(keep bool) {
+	keepWork = keep
+}
+
+// NewWorkspace creates a new empty workspace inside the work root,
+// its name starts with `prefix`.
+func NewWorkspace(prefix string) (string, error) {
+	if err := os.MkdirAll(WorkRoot(), 0777); err != nil {
+		return "", err
+	}
+	return ioutil.TempDir(WorkRoot(), prefix+"-")
+}
+
+// CloseWorkspace removes the workspace `dir`.
+// If the work `failed` and the workspaces must be kept the
+// directory is left in place and its path printed.
+func CloseWorkspace(dir string, failed bool) {
+	if failed && keepWork {
+		fmt.Println("Workspace kept: " + dir)
+		return
+	}
+	os.RemoveAll(dir)
+}
+
+// CacheDir is the directory where effe-tool keeps data between
+// runs, `effe-tool clean` removes it.
+// The go tool keeps there the build cache of the effes, see
+// GoCacheDir.
+func CacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "effe-tool")
+}
+
+// GoCacheDir is the GOCACHE of the builds of the effes, kept apart
+// from the one of the user so that `effe-tool clean` can remove it.
+func GoCacheDir() string {
+	return filepath.Join(CacheDir(), "go-build")
+}
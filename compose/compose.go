@@ -0,0 +1,303 @@
+// Package compose writes the docker-compose.yml that runs a group of
+// effes together, from their executables.
+//
+// Every effe is a service with the image built by the docker command,
+// published on its own port of the host, with the parameters read
+// from the environment and a healthcheck. An optional nginx service
+// routes the paths of the effes to their services.
+package compose
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/commons"
+	"github.com/siscia/effe-tool/docker"
+	"github.com/siscia/effe-tool/oci"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func logError(path, msg string) {
+	fmt.Println("File: " + path + " | " + msg)
+}
+
+// ProxyConfig is the nginx configuration of the proxy, written next
+// to the compose file.
+const ProxyConfig = "effe-proxy.conf"
+
+// service is an effe inside the compose file.
+type service struct {
+	name  string
+	path  string
+	image string
+	info  commons.Info
+	// port is where the effe listens inside the container, hostPort
+	// where it is published
+	port     int
+	hostPort int
+	env      map[string]string
+}
+
+// location is a path of the proxy and the service that serves it.
+type location struct {
+	path    string
+	exact   bool
+	service *service
+	// strip removes the path before passing the request to the effe
+	strip bool
+}
+
+// newService describes the effe at `path`, `taken` are the names of
+// the services found so far.
+func newService(path string, taken map[string]bool, c *cli.Context) (*service, error) {
+	log := func(msg string) {
+		logError(path, msg)
+	}
+	info, err := commons.ExecutableInfo(path)
+	if err != nil {
+		return nil, errors.New("Not an effe, skipped.")
+	}
+	tags, config, err := docker.ImageOf(path, info, c)
+	if err != nil {
+		return nil, err
+	}
+	// the service is named like the image, and it is also the host
+	// name of the effe, so without dots and underscores
+	ref := oci.ParseReference(tags[0])
+	name := strings.NewReplacer(".", "-", "_", "-").Replace(ref.Repository[strings.LastIndex(ref.Repository, "/")+1:])
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = name + "-" + strconv.Itoa(i)
+	}
+	if unique != name {
+		log("The service " + name + " already exists, using " + unique + ".")
+	}
+	taken[unique] = true
+
+	return &service{
+		name:  unique,
+		path:  path,
+		image: tags[0],
+		info:  info,
+		port:  config.Port,
+		env:   config.Env,
+	}, nil
+}
+
+// environment are the variables of the service: the parameters of the
+// effe read from the environment, taken from the one of compose.
+// A required parameter stops compose when it is missing, the other
+// ones default to the value in the image, if any.
+func (s *service) environment() map[string]string {
+	env := map[string]string{}
+	for _, param := range s.info.Params {
+		if param.In != "env" {
+			continue
+		}
+		if param.Required {
+			env[param.Name] = "${" + param.Name + ":?" + s.name + " needs " + param.Name + "}"
+		} else {
+			value := strings.Replace(s.env[param.Name], "$", "$$", -1)
+			env[param.Name] = "${" + param.Name + ":-" + value + "}"
+		}
+	}
+	return env
+}
+
+// routePath returns the static part of the path of a route and
+// whether it is the whole path: /users/{id} gives /users/.
+func routePath(path string) (string, bool) {
+	i := strings.IndexAny(path, "{*:")
+	if i < 0 {
+		return path, true
+	}
+	return path[:strings.LastIndex(path[:i], "/")+1], false
+}
+
+// locations are the paths that the proxy routes to the services: the
+// routes in the Info of the effes or, without routes, /service/,
+// removed from the request. A path already taken by an effe is not
+// routed to the others.
+func locations(services []*service) []location {
+	locations := []location{}
+	taken := map[string]string{}
+	for _, s := range services {
+		paths := []location{}
+		for _, route := range s.info.Routes {
+			path, exact := routePath(route.Path)
+			if !strings.HasPrefix(path, "/") {
+				logError(s.path, "The route "+route.Path+" does not start with /, skipped by the proxy.")
+				continue
+			}
+			paths = append(paths, location{path: path, exact: exact, service: s})
+		}
+		if len(paths) == 0 {
+			paths = append(paths, location{path: "/" + s.name + "/", service: s, strip: true})
+		}
+		for _, l := range paths {
+			key := l.path
+			if l.exact {
+				key = "= " + key
+			}
+			if owner, ok := taken[key]; ok {
+				if owner != s.name {
+					logError(s.path, "The path "+l.path+" is already routed to "+owner+", skipped by the proxy.")
+				}
+				continue
+			}
+			taken[key] = s.name
+			locations = append(locations, l)
+		}
+	}
+	return locations
+}
+
+// proxyConfig is the nginx configuration that routes the locations.
+func proxyConfig(locations []location) string {
+	var out bytes.Buffer
+	out.WriteString("# Generated by effe-tool compose.\nserver {\n    listen 80;\n")
+	for _, l := range locations {
+		modifier := ""
+		if l.exact {
+			modifier = "= "
+		}
+		upstream := "http://" + l.service.name + ":" + strconv.Itoa(l.service.port)
+		if l.strip {
+			upstream += "/"
+		}
+		out.WriteString("\n    location " + modifier + l.path + " {\n")
+		out.WriteString("        proxy_pass " + upstream + ";\n")
+		out.WriteString("        proxy_set_header Host $host;\n")
+		out.WriteString("        proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;\n")
+		out.WriteString("    }\n")
+	}
+	out.WriteString("}\n")
+	return out.String()
+}
+
+func quote(s string) string {
+	return strconv.Quote(s)
+}
+
+// composeFile is the docker-compose.yml of the services, with the
+// proxy published on `proxyPort` unless it is 0.
+func composeFile(services []*service, proxyImage string, proxyPort int) string {
+	var out bytes.Buffer
+	out.WriteString("# Generated by effe-tool compose.\nservices:\n")
+	for _, s := range services {
+		port := strconv.Itoa(s.port)
+		out.WriteString("  " + s.name + ":\n")
+		out.WriteString("    image: " + quote(s.image) + "\n")
+		out.WriteString("    ports:\n")
+		out.WriteString("      - " + quote(strconv.Itoa(s.hostPort)+":"+port) + "\n")
+		if env := s.environment(); len(env) > 0 {
+			names := []string{}
+			for name := range env {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			out.WriteString("    environment:\n")
+			for _, name := range names {
+				out.WriteString("      " + quote(name) + ": " + quote(env[name]) + "\n")
+			}
+		}
+		out.WriteString("    healthcheck:\n")
+		out.WriteString("      test: [\"CMD\", \"/exec\", \"-probe\", \"-port\", " + quote(port) + "]\n")
+		out.WriteString("      interval: 30s\n      timeout: 5s\n      start_period: 5s\n      retries: 3\n")
+		out.WriteString("    restart: unless-stopped\n")
+	}
+	if proxyPort == 0 {
+		return out.String()
+	}
+	out.WriteString("  proxy:\n")
+	out.WriteString("    image: " + quote(proxyImage) + "\n")
+	out.WriteString("    ports:\n")
+	out.WriteString("      - " + quote(strconv.Itoa(proxyPort)+":80") + "\n")
+	out.WriteString("    volumes:\n")
+	out.WriteString("      - " + quote("./"+ProxyConfig+":/etc/nginx/conf.d/default.conf:ro") + "\n")
+	out.WriteString("    depends_on:\n")
+	for _, s := range services {
+		out.WriteString("      " + s.name + ":\n        condition: service_healthy\n")
+	}
+	out.WriteString("    restart: unless-stopped\n")
+	return out.String()
+}
+
+// Compose is the main entry point, it writes the compose file of a
+// single executable or of every effe in the directory passed as
+// argument.
+func Compose(c *cli.Context) {
+	path := c.Args().First()
+	f, err := os.Lstat(path)
+	if err != nil {
+		fmt.Println("File: " + path + " | Impossible to open the file, does it exists ?")
+		return
+	}
+
+	proxyPort := 0
+	if c.Bool("proxy") {
+		proxyPort = c.Int("proxy-port")
+	}
+	services := []*service{}
+	taken := map[string]bool{"proxy": c.Bool("proxy")}
+	add := func(path string) {
+		s, err := newService(path, taken, c)
+		if err != nil {
+			logError(path, err.Error())
+			return
+		}
+		services = append(services, s)
+	}
+	if f.IsDir() {
+		filepath.Walk(path, func(path string, f os.FileInfo, _ error) error {
+			if f.Mode().IsRegular() && f.Mode()&0111 != 0 {
+				add(path)
+			}
+			return nil
+		})
+	}
+	if f.Mode().IsRegular() {
+		add(path)
+	}
+	if len(services) == 0 {
+		fmt.Println("File: " + path + " | No effe found, no compose file written.")
+		return
+	}
+
+	// every effe gets the next free port of the host
+	hostPort := c.Int("host-port")
+	for _, s := range services {
+		for hostPort == proxyPort {
+			hostPort++
+		}
+		s.hostPort = hostPort
+		logError(s.path, "Service "+s.name+" with image "+s.image+" on port "+strconv.Itoa(hostPort)+".")
+		hostPort++
+	}
+
+	output := c.String("output")
+	if err := os.MkdirAll(filepath.Dir(output), 0777); err != nil {
+		fmt.Println("File: " + output + " | Impossible to create the directory.")
+		return
+	}
+	if proxyPort != 0 {
+		config := filepath.Join(filepath.Dir(output), ProxyConfig)
+		if err := ioutil.WriteFile(config, []byte(proxyConfig(locations(services))), 0644); err != nil {
+			fmt.Println("File: " + config + " | Impossible to write the proxy configuration.")
+			fmt.Println(err)
+			return
+		}
+	}
+	if err := ioutil.WriteFile(output, []byte(composeFile(services, c.String("proxy-image"), proxyPort)), 0644); err != nil {
+		fmt.Println("File: " + output + " | Impossible to write the compose file.")
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("File: " + output + " | Everything went good, " + strconv.Itoa(len(services)) + " services.")
+}
@@ -1,111 +1,622 @@
 package docker
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/builder"
 	"github.com/siscia/effe-tool/commons"
+	"github.com/siscia/effe-tool/naming"
+	"github.com/siscia/effe-tool/oci"
+	"github.com/siscia/effe-tool/sources"
+	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
 )
 
 func logError(path, msg string) {
 	fmt.Println("File: " + path + " | " + msg)
 }
 
-func dockerFile(path string) string {
-	return `
-FROM centurylink/ca-certs
+// DefaultUser runs the effes, it is the nonroot user of the
+// distroless images; scratch has no /etc/passwd so it is numeric.
+const DefaultUser = "65532:65532"
 
-ADD exec exec
+// numericUser is a user as uid or uid:gid.
+var numericUser = regexp.MustCompile(`^[0-9]+(:[0-9]+)?$`)
 
-ENTRYPOINT ["/exec"]
-`
+// imageUser returns the user of the image of `config` built by
+// `engine`: root is 0:0 and the other names need an /etc/passwd to
+// be looked up, missing in scratch and in the images of the oci
+// engine.
+func imageUser(config commons.DockerConfig, engine Engine) (string, error) {
+	if config.User == "root" {
+		return "0:0", nil
+	}
+	passwd := engine.Name() != "oci" && (config.Dockerfile != "" || config.Variant != "scratch" || config.BaseImage != "")
+	if !passwd && !numericUser.MatchString(config.User) {
+		return "", errors.New("The user " + config.User + " is not numeric and the image has no /etc/passwd, use uid:gid.")
+	}
+	return config.User, nil
 }
 
-func dockerifyDirectory(originalPath string, c *cli.Context) {
-	log := func(msg string) {
-		logError(originalPath, msg)
+// Variants are the built-in Dockerfile templates.
+var Variants = []string{"scratch", "distroless", "alpine"}
+
+// certsFile is the name of the CA bundle of the host inside the build
+// context.
+const certsFile = "ca-certificates.crt"
+
+// dockerfileData is what the Dockerfile templates can use, besides
+// the fields of Info: Exec is the name of the executable inside the
+// build context and Certs the one of the CA bundle of the host, empty
+// if it has none; Entrypoint and Probe are the commands that run and
+// check the effe.
+type dockerfileData struct {
+	commons.Info
+	Exec       string
+	Certs      string
+	BaseImage  string
+	Port       int
+	User       string
+	Env        map[string]string
+	Labels     map[string]string
+	Entrypoint []string
+	Probe      []string
+}
+
+// dockerQuote quotes `value` as a double quoted string of a
+// Dockerfile, where the backslash escapes the quotes, itself and the
+// variables; a Dockerfile has no way to write a new line.
+func dockerQuote(value string) (string, error) {
+	if strings.ContainsAny(value, "\r\n") {
+		return "", errors.New("The value " + strconv.Quote(value) + " has a new line, it can't go in a Dockerfile")
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`).Replace(value) + `"`, nil
+}
+
+// dockerJSON writes `value` as JSON, like the arguments of the exec
+// form of ENTRYPOINT, CMD and HEALTHCHECK.
+func dockerJSON(value interface{}) (string, error) {
+	content, err := json.Marshal(value)
+	return string(content), err
+}
+
+// parsePairs parses the `key=value` pairs of a flag.
+func parsePairs(flag string, pairs []string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.New("Wrong --" + flag + " " + pair + ", it must be key=value")
+		}
+		values[parts[0]] = parts[1]
+	}
+	return values, nil
+}
+
+// dockerConfig returns the configuration of the image of the
+// executable at `path`: the one in effe.json, if the executable is
+// inside a project, overridden by the flags.
+func dockerConfig(path string, c *cli.Context) (commons.DockerConfig, error) {
+	config := commons.DockerConfig{Variant: "scratch", Port: 8080, User: DefaultUser}
+	if dir, m, err := commons.FindManifest(path); err == nil {
+		config.Engine = m.Docker.Engine
+		config.Registry = m.Docker.Registry
+		config.Repository = m.Docker.Repository
+		config.Tags = m.Docker.Tags
+		if m.Docker.Variant != "" {
+			config.Variant = m.Docker.Variant
+		}
+		if m.Docker.Port != 0 {
+			config.Port = m.Docker.Port
+		}
+		if m.Docker.User != "" {
+			config.User = m.Docker.User
+		}
+		if m.Docker.Dockerfile != "" {
+			config.Dockerfile = filepath.Join(dir, m.Docker.Dockerfile)
+		}
+		config.BaseImage = m.Docker.BaseImage
+		config.Env = m.Docker.Env
+		config.Labels = m.Docker.Labels
+	}
+	if config.Env == nil {
+		config.Env = map[string]string{}
+	}
+	if config.Labels == nil {
+		config.Labels = map[string]string{}
+	}
+
+	if c.String("engine") != "" {
+		config.Engine = c.String("engine")
+	}
+	if c.String("registry") != "" {
+		config.Registry = c.String("registry")
+	}
+	if c.String("repository") != "" {
+		config.Repository = c.String("repository")
+	}
+	if c.String("tag-policy") != "" {
+		config.Tags = strings.Split(c.String("tag-policy"), ",")
+	}
+	if c.IsSet("variant") {
+		// a variant asked explicitly wins over the Dockerfile of the project
+		config.Variant = c.String("variant")
+		config.Dockerfile = ""
+	}
+	if c.String("base-image") != "" {
+		config.BaseImage = c.String("base-image")
+	}
+	if c.String("dockerfile") != "" {
+		config.Dockerfile = c.String("dockerfile")
+	}
+	if c.IsSet("port") {
+		config.Port = c.Int("port")
+	}
+	if c.String("user") != "" {
+		config.User = c.String("user")
+	}
+	env, err := parsePairs("env", c.StringSlice("env"))
+	if err != nil {
+		return config, err
+	}
+	for key, value := range env {
+		config.Env[key] = value
+	}
+	labels, err := parsePairs("label", c.StringSlice("label"))
+	if err != nil {
+		return config, err
+	}
+	for key, value := range labels {
+		config.Labels[key] = value
+	}
+	return config, nil
+}
+
+// dockerFile renders the Dockerfile of the effe described by `info`,
+// from the template of the project if there is one, otherwise from
+// the built-in variant.
+func dockerFile(info commons.Info, config commons.DockerConfig) (string, error) {
+	var source string
+	if config.Dockerfile != "" {
+		content, err := ioutil.ReadFile(config.Dockerfile)
+		if err != nil {
+			return "", err
+		}
+		source = string(content)
+	} else {
+		content, err := sources.Dockerfile(config.Variant)
+		if err != nil {
+			return "", errors.New("Unknown variant " + config.Variant + ", the built-in ones are: " + strings.Join(Variants, ", "))
+		}
+		source = content
+	}
+	t, err := template.New("Dockerfile").Funcs(template.FuncMap{"quote": dockerQuote, "json": dockerJSON}).Parse(source)
+	if err != nil {
+		return "", err
 	}
 
+	port := strconv.Itoa(config.Port)
+	data := dockerfileData{
+		Info:       info,
+		Exec:       "exec",
+		BaseImage:  config.BaseImage,
+		Port:       config.Port,
+		User:       config.User,
+		Env:        config.Env,
+		Labels:     config.Labels,
+		Entrypoint: []string{oci.ExecPath, "-port", port},
+		Probe:      []string{oci.ExecPath, "-probe", "-port", port},
+	}
+	if oci.FindCerts() != "" {
+		data.Certs = certsFile
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// target is an executable to put in an image.
+type target struct {
+	path string
+	// source is the source of the executable, empty when the
+	// executable is given
+	source string
+	info   commons.Info
+	// core is the version of the core of an executable built from its
+	// source, empty when the executable is given
+	core string
+	// platforms are the executables of a multi-platform image, path
+	// is one of them
+	platforms []platformExec
+}
+
+// platformExec is the executable of a platform.
+type platformExec struct {
+	platform string
+	path     string
+}
+
+// origin is what the user passed: the source or the executable.
+func (t target) origin() string {
+	if t.source != "" {
+		return t.source
+	}
+	return t.path
+}
+
+// digest returns the sha256 of the executable; for a multi-platform
+// image it is the sha256 of every platform, sorted, with the sha256 of
+// its executable, so that it changes when any of them changes.
+func (t target) digest() (string, error) {
+	if len(t.platforms) <= 1 {
+		return commons.FileSHA256(t.path)
+	}
+	platforms := append([]platformExec{}, t.platforms...)
+	sort.Slice(platforms, func(i, j int) bool {
+		return platforms[i].platform < platforms[j].platform
+	})
+	h := sha256.New()
+	for _, p := range platforms {
+		sum, err := commons.FileSHA256(p.path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s %s\n", p.platform, sum)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// reportEntry is an image in the report of --report.
+type reportEntry struct {
+	// Path is the path of the executable, images built from a source
+	// have only the Source since their executable is not kept
+	Path   string `json:"path,omitempty"`
+	Source string `json:"source,omitempty"`
+	// Reference is a tag of the image
+	Reference string `json:"reference"`
+	// Platforms are the platforms of a multi-platform image
+	Platforms []string `json:"platforms,omitempty"`
+	// Digest is set once the image is pushed
+	Digest string `json:"digest,omitempty"`
+}
+
+// options are the flags of the docker command that do not describe
+// the image.
+type options struct {
+	dryRun bool
+	push   bool
+	// tags replace the references computed from the Info
+	tags []string
+}
+
+// dockerifyDirectory builds the images of the effes inside the
+// directory, the other files are skipped.
+func dockerifyDirectory(originalPath string, c *cli.Context) (report []reportEntry) {
 	walkAndDockerify := func(path string, f os.FileInfo, _ error) error {
-		if f.IsDir() {
+		if !f.Mode().IsRegular() || f.Mode()&0111 == 0 {
 			return nil
 		}
-		if f.Mode().IsRegular() {
-			fmt.Println()
-			err := dockerifyExec(path, c)
-			if err != nil {
-				log("Error dockerifying the file.")
-			}
+		fmt.Println()
+		info, err := commons.ExecutableInfo(path)
+		if err != nil {
+			logError(path, "Not an effe, skipped.")
+			return nil
 		}
+		entries, err := dockerifyTarget(target{path: path, info: info}, c)
+		if err != nil {
+			logError(path, "Error dockerifying the file.")
+		}
+		report = append(report, entries...)
 		return nil
 	}
 	filepath.Walk(originalPath, walkAndDockerify)
+	return report
 }
 
-func dockerGetCompleteName(path string) string {
-	name, version, err := commons.GetNameVersion(path)
-	if (err != nil) || (name == "") {
-		hash, err := commons.ExecutableHash(path)
+func dockerifyExec(path string, c *cli.Context) ([]reportEntry, error) {
+	// a broken Info is handled by naming.Effe
+	info, _ := commons.ExecutableInfo(path)
+	return dockerifyTarget(target{path: path, info: info}, c)
+}
+
+// parsePlatforms parses the comma separated platforms of --platform.
+func parsePlatforms(flag string) ([]oci.Platform, error) {
+	platforms := []oci.Platform{}
+	seen := map[string]bool{}
+	for _, value := range strings.Split(flag, ",") {
+		p, err := oci.ParsePlatform(strings.TrimSpace(value))
 		if err != nil {
-			name = filepath.Base(path)
-			return name
+			return nil, err
 		}
-		return hash
-	}
-	if (name != "") && (version != "") {
-		return name + ":" + version
+		if seen[p.String()] {
+			return nil, errors.New("The platform " + p.String() + " is repeated")
+		}
+		seen[p.String()] = true
+		platforms = append(platforms, p)
 	}
-	return filepath.Base(path)
+	return platforms, nil
 }
 
-func dockerifyExec(path string, c *cli.Context) error {
+// staticOptions are the options to compile the effe at `source` for
+// its image: the ones of the flags and of effe.json, static.
+func staticOptions(source string, c *cli.Context) (builder.Options, error) {
+	opts, err := builder.LoadOptions(source, c)
+	if err != nil {
+		return opts, err
+	}
+	return opts, opts.Static()
+}
 
+// dockerifySource compiles the effe at `source` as a static linux
+// executable, whatever the host, and builds its image; with
+// --platform it compiles an executable for each platform and builds
+// a multi-platform image.
+func dockerifySource(source string, c *cli.Context) (_ []reportEntry, err error) {
 	log := func(msg string) {
-		logError(path, msg)
+		logError(source, msg)
 	}
 
-	// Creating the temporany dir and the whole struct
-	dir := os.TempDir() + "/effedocker-" + commons.RandomSuffix()
-	if err := os.Mkdir(dir, 0777); err != nil {
-		log("Impossible to create temporany dir")
-		return err
+	info, err := commons.SourceInfo(source)
+	if err != nil {
+		log("Not an effe, skipped.")
+		return nil, nil
+	}
+	opts, err := staticOptions(source, c)
+	if err != nil {
+		log(err.Error())
+		return nil, err
+	}
+	core := commons.CoreVersion(sources.Core)
+	if c.String("platform") == "" {
+		var execPath string
+		execPath, err = builder.CompileSingleFile(source, opts)
+		if err != nil {
+			log("Impossible to compile.")
+			return nil, err
+		}
+		defer func() {
+			commons.CloseWorkspace(filepath.Dir(execPath), err != nil)
+		}()
+		return dockerifyTarget(target{path: execPath, source: source, info: info, core: core}, c)
 	}
 
-	// Create the Dockerfile in the directory
-	if err := commons.NewFile(dir+"/Dockerfile", dockerFile(path)); err != nil {
-		log("Impossible to create the dockerfile in the temporany dir: " + dir)
-		return err
+	platforms, err := parsePlatforms(c.String("platform"))
+	if err != nil {
+		log(err.Error())
+		return nil, err
 	}
+	t := target{source: source, info: info, core: core}
+	for _, p := range platforms {
+		o := opts
+		o.GOOS, o.GOARCH, o.GOARM = p.OS, p.Architecture, ""
+		if p.Architecture == "arm" {
+			o.GOARM = strings.TrimPrefix(p.Variant, "v")
+		}
+		var execPath string
+		execPath, err = builder.CompileSingleFile(source, o)
+		if err != nil {
+			log("Impossible to compile for " + p.String() + ".")
+			return nil, err
+		}
+		defer func() {
+			commons.CloseWorkspace(filepath.Dir(execPath), err != nil)
+		}()
+		t.platforms = append(t.platforms, platformExec{platform: p.String(), path: execPath})
+		if t.path == "" {
+			t.path = execPath
+		}
+	}
+	return dockerifyTarget(t, c)
+}
 
-	// Move the executable to the directory
-	if err := os.Link(path, dir+"/exec"); err != nil {
-		log("Impossible to move the file to the temporany directory: " + dir)
-		return err
+// dockerifySourceDirectory builds the images of every source inside
+// the directory, except the ones in .effeignore.
+func dockerifySourceDirectory(originalPath string, c *cli.Context) (report []reportEntry) {
+	ignore := commons.LoadIgnore(originalPath)
+	walkAndDockerify := func(path string, f os.FileInfo, _ error) error {
+		if ignore.Match(path) {
+			if f.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !f.Mode().IsRegular() || filepath.Ext(path) != ".go" {
+			return nil
+		}
+		fmt.Println()
+		entries, err := dockerifySource(path, c)
+		if err != nil {
+			logError(path, "Error dockerifying the file.")
+		}
+		report = append(report, entries...)
+		return nil
 	}
+	filepath.Walk(originalPath, walkAndDockerify)
+	return report
+}
 
-	name := dockerGetCompleteName(path)
+func dockerifyTarget(t target, c *cli.Context) ([]reportEntry, error) {
+	config, err := dockerConfig(t.origin(), c)
+	if err != nil {
+		logError(t.origin(), err.Error())
+		return nil, err
+	}
+	engine, err := NewEngine(config.Engine, c.String("output"), c.String("oci-format"))
+	if err != nil {
+		logError(t.origin(), err.Error())
+		return nil, err
+	}
+	if config.Engine == "" {
+		logError(t.origin(), "Using the "+engine.Name()+" engine.")
+	}
+	return dockerify(t, config, engine, options{
+		dryRun: c.Bool("dry-run"),
+		push:   c.Bool("push"),
+		tags:   c.StringSlice("tag"),
+	})
+}
 
-	cmd := exec.Command("docker", "build", "-t", name, dir)
+// dockerify builds with `engine` the image of `t` and pushes it, or
+// with `dryRun` prints what would be built.
+func dockerify(t target, config commons.DockerConfig, engine Engine, opts options) ([]reportEntry, error) {
+	log := func(msg string) {
+		logError(t.origin(), msg)
+	}
 
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	info := t.info
+	name, version, err := naming.Effe(info, t.path, log)
+	if err != nil {
+		log(err.Error())
+		return nil, err
+	}
+	if info.Name == "" {
+		info.Name = name
+	}
+	if config.User, err = imageUser(config, engine); err != nil {
+		log(err.Error())
+		return nil, err
+	}
+	p := executableProvenance(t.origin())
+	tags, err := imageTags(t, name, version, p, config, opts.tags)
+	if err != nil {
+		log(err.Error())
+		return nil, err
+	}
+	// the labels set by the user win over the ones of the effe
+	labels := imageLabels(t.path, t.core, info, p, config.Port)
+	for key, value := range config.Labels {
+		labels[key] = value
+	}
+	config.Labels = labels
 
-	if err := cmd.Start(); err != nil {
-		log("Problem invoking docker: " + path)
+	dockerfile, err := dockerFile(info, config)
+	if err != nil {
+		log("Impossible to create the dockerfile.")
 		fmt.Println(err)
-		return err
+		return nil, err
+	}
+	image := Image{
+		Path:       t.path,
+		Tags:       tags,
+		Config:     config,
+		Dockerfile: dockerfile,
+	}
+	platforms := []string{}
+	for _, p := range t.platforms {
+		platform := image
+		platform.Path, platform.Platform, platform.Platforms = p.path, p.platform, nil
+		image.Platforms = append(image.Platforms, platform)
+		platforms = append(platforms, p.platform)
 	}
 
-	if err := cmd.Wait(); err != nil {
-		log("Problem with docker: " + path)
-		fmt.Println(err)
-		return err
+	if opts.dryRun {
+		if len(platforms) > 0 {
+			log("Platforms: " + strings.Join(platforms, ", "))
+		}
+		plan, err := engine.Plan(image)
+		if err != nil {
+			log(err.Error())
+			return nil, err
+		}
+		fmt.Print(plan)
+		return nil, nil
 	}
 
-	log("Everything went good: " + dir)
-	return nil
+	result, err := engine.Build(image)
+	if err != nil {
+		log(err.Error())
+		return nil, err
+	}
+	log("Everything went good: " + result)
+	report := []reportEntry{}
+	for _, tag := range tags {
+		entry := reportEntry{Source: t.source, Reference: tag}
+		if len(platforms) > 0 {
+			entry.Platforms = platforms
+		}
+		if t.source == "" {
+			entry.Path = t.path
+		}
+		report = append(report, entry)
+	}
+	if !opts.push {
+		return report, nil
+	}
+
+	images, err := engine.Push(image)
+	for _, image := range images {
+		log("Pushed " + image.Reference + "@" + image.Digest)
+		for i := range report {
+			if report[i].Reference == image.Reference {
+				report[i].Digest = image.Digest
+			}
+		}
+	}
+	if err != nil {
+		log(err.Error())
+		return report, err
+	}
+	return report, nil
+}
+
+// ImageOf returns the references of the image that the docker
+// command builds with --from-source for the effe at `source`, whose
+// Info is `info`, and the configuration of the image; `c` holds the
+// flags of docker that name and describe the image.
+// The digest tag, and the name of an effe without one, come from the
+// executable: in that case the effe is compiled as --from-source does.
+func ImageOf(source string, info commons.Info, c *cli.Context) (_ []string, config commons.DockerConfig, err error) {
+	config, err = dockerConfig(source, c)
+	if err != nil {
+		return nil, config, err
+	}
+	t := target{source: source, info: info}
+	explicit := c.StringSlice("tag")
+	if info.Name == "" || (len(explicit) == 0 && hasPolicy(config.Tags, "digest")) {
+		opts, err := staticOptions(source, c)
+		if err != nil {
+			return nil, config, err
+		}
+		execPath, err := builder.CompileSingleFile(source, opts)
+		if err != nil {
+			return nil, config, err
+		}
+		defer commons.CloseWorkspace(filepath.Dir(execPath), false)
+		t.path = execPath
+	}
+	name, version, err := naming.Effe(info, t.path, func(msg string) {
+		logError(source, msg)
+	})
+	if err != nil {
+		return nil, config, err
+	}
+	tags, err := imageTags(t, name, version, executableProvenance(source), config, explicit)
+	return tags, config, err
+}
+
+// writeReport writes the images as JSON in `path`.
+func writeReport(path string, report []reportEntry) error {
+	if report == nil {
+		report = []reportEntry{}
+	}
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(content, '\n'), 0644)
 }
 
 func Dockerify(c *cli.Context) {
@@ -115,10 +626,25 @@ func Dockerify(c *cli.Context) {
 		fmt.Println("File: " + path + " | Impossible to open the file, does it exists ?")
 		return
 	}
-	if f.IsDir() {
-		dockerifyDirectory(path, c)
+	if c.String("platform") != "" && !c.Bool("from-source") {
+		fmt.Println("File: " + path + " | --platform needs --from-source, an executable runs on a single platform.")
+		return
 	}
-	if f.Mode().IsRegular() {
-		dockerifyExec(path, c)
+	var report []reportEntry
+	switch {
+	case c.Bool("from-source") && f.IsDir():
+		report = dockerifySourceDirectory(path, c)
+	case c.Bool("from-source") && f.Mode().IsRegular():
+		report, _ = dockerifySource(path, c)
+	case f.IsDir():
+		report = dockerifyDirectory(path, c)
+	case f.Mode().IsRegular():
+		report, _ = dockerifyExec(path, c)
+	}
+	if c.String("report") != "" {
+		if err := writeReport(c.String("report"), report); err != nil {
+			fmt.Println("File: " + c.String("report") + " | Impossible to write the report.")
+			fmt.Println(err)
+		}
 	}
 }
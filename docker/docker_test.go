@@ -0,0 +1,385 @@
+package docker
+
+import (
+	"errors"
+	"github.com/siscia/effe-tool/commons"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var usersInfo = commons.Info{
+	Name:    "users",
+	Version: "1.2",
+	Doc:     "Users API.",
+	Routes:  []commons.Route{{Method: "GET", Path: "/users/{id}"}},
+	Params:  []commons.Param{{Name: "DB_URL", In: "env"}},
+}
+
+// newTarget writes a fake executable inside a new directory and
+// returns it as the target of dockerify.
+func newTarget(t *testing.T) target {
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	path := filepath.Join(t.TempDir(), "users_v1.2")
+	if err := ioutil.WriteFile(path, []byte("not really an effe"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return target{path: path, info: usersInfo, core: "0123456789ab"}
+}
+
+func newConfig() commons.DockerConfig {
+	return commons.DockerConfig{
+		Variant: "scratch",
+		Port:    8080,
+		User:    DefaultUser,
+		Env:     map[string]string{},
+		Labels:  map[string]string{},
+	}
+}
+
+func TestDockerifyImage(t *testing.T) {
+	target := newTarget(t)
+	config := newConfig()
+	config.Port = 9000
+	config.User = "1000:1000"
+	config.Env["LOG_LEVEL"] = `say "hi" to $USER`
+	config.Labels["team"] = "payments"
+	config.Labels[ociLabel+"title"] = "Users"
+
+	engine := &Fake{}
+	if _, err := dockerify(target, config, engine, options{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(engine.Images) != 1 {
+		t.Fatalf("built %d images, want 1", len(engine.Images))
+	}
+	image := engine.Images[0]
+	if image.Path != target.path {
+		t.Errorf("path %s, want %s", image.Path, target.path)
+	}
+
+	for _, line := range []string{
+		"FROM scratch\n",
+		"COPY exec /exec\n",
+		`ENV LOG_LEVEL="say \"hi\" to \$USER"` + "\n",
+		`LABEL "team"="payments"` + "\n",
+		"EXPOSE 9000\n",
+		"USER 1000:1000\n",
+		`HEALTHCHECK --interval=30s --timeout=5s --start-period=5s --retries=3 CMD ["/exec","-probe","-port","9000"]` + "\n",
+		`ENTRYPOINT ["/exec","-port","9000"]` + "\n",
+	} {
+		if !strings.Contains(image.Dockerfile, line) {
+			t.Errorf("the Dockerfile has no %q:\n%s", line, image.Dockerfile)
+		}
+	}
+
+	labels := map[string]string{
+		ociLabel + "title":       "Users",
+		ociLabel + "version":     "1.2",
+		ociLabel + "description": "Users API.",
+		ociLabel + "created":     "1970-01-01T00:00:00Z",
+		effeLabel + "core":       "0123456789ab",
+		effeLabel + "port":       "9000",
+		effeLabel + "routes":     `[{"method":"GET","path":"/users/{id}"}]`,
+		effeLabel + "params":     `[{"name":"DB_URL","in":"env"}]`,
+		"team":                   "payments",
+	}
+	if !reflect.DeepEqual(image.Config.Labels, labels) {
+		t.Errorf("labels %v, want %v", image.Config.Labels, labels)
+	}
+}
+
+// TestDockerfileReadOnly checks that the Dockerfiles of every variant
+// declare no volume and no working directory, the containers run with
+// a read-only root filesystem.
+func TestDockerfileReadOnly(t *testing.T) {
+	allowed := map[string]bool{
+		"FROM": true, "RUN": true, "COPY": true, "ENV": true, "LABEL": true,
+		"EXPOSE": true, "USER": true, "HEALTHCHECK": true, "ENTRYPOINT": true,
+	}
+	for _, variant := range []string{"scratch", "distroless", "alpine"} {
+		config := newConfig()
+		config.Variant = variant
+		engine := &Fake{}
+		if _, err := dockerify(newTarget(t), config, engine, options{}); err != nil {
+			t.Fatalf("%s: %s", variant, err)
+		}
+		for _, line := range strings.Split(engine.Images[0].Dockerfile, "\n") {
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if instruction := strings.Fields(line)[0]; !allowed[instruction] {
+				t.Errorf("%s: the Dockerfile has %q", variant, line)
+			}
+		}
+	}
+}
+
+func TestDockerifyQuoteNewLine(t *testing.T) {
+	config := newConfig()
+	config.Env["MOTD"] = "hello\nworld"
+	engine := &Fake{}
+	if _, err := dockerify(newTarget(t), config, engine, options{}); err == nil {
+		t.Fatal("a new line in the environment built an image")
+	}
+	if len(engine.Images) != 0 {
+		t.Errorf("built %d images, want none", len(engine.Images))
+	}
+}
+
+func TestDockerifyUser(t *testing.T) {
+	cases := []struct {
+		user      string
+		variant   string
+		baseImage string
+		engine    string
+		want      string
+		wantErr   bool
+	}{
+		{user: DefaultUser, variant: "scratch", want: DefaultUser},
+		{user: "1000", variant: "scratch", want: "1000"},
+		{user: "root", variant: "scratch", want: "0:0"},
+		{user: "root", variant: "distroless", engine: "oci", want: "0:0"},
+		{user: "nobody", variant: "scratch", wantErr: true},
+		{user: "nobody", variant: "distroless", engine: "oci", wantErr: true},
+		{user: "nobody", variant: "scratch", baseImage: "busybox", want: "nobody"},
+		{user: "nobody", variant: "alpine", want: "nobody"},
+	}
+	for _, c := range cases {
+		config := newConfig()
+		config.User = c.user
+		config.Variant = c.variant
+		config.BaseImage = c.baseImage
+		engine := &Fake{}
+		var e Engine = engine
+		if c.engine == "oci" {
+			e = ociNamed{engine}
+		}
+		_, err := dockerify(newTarget(t), config, e, options{})
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%s on %s: no error", c.user, c.variant)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s on %s: %s", c.user, c.variant, err)
+			continue
+		}
+		image := engine.Images[0]
+		if image.Config.User != c.want || !strings.Contains(image.Dockerfile, "USER "+c.want+"\n") {
+			t.Errorf("%s on %s: user %s, want %s", c.user, c.variant, image.Config.User, c.want)
+		}
+	}
+}
+
+// ociNamed is the Fake engine that goes by the name of the oci one.
+type ociNamed struct {
+	*Fake
+}
+
+func (ociNamed) Name() string {
+	return "oci"
+}
+
+// commit makes the directory of the target a git repository with a
+// commit and returns its revision.
+func commit(t *testing.T, target target) string {
+	dir := filepath.Dir(target.path)
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=effe", "-c", "user.email=effe@example.com", "commit", "-q", "-m", "users"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Skip("git is not usable: " + string(out))
+		}
+	}
+	return git(dir, "rev-parse", "HEAD")
+}
+
+func TestDockerifyTags(t *testing.T) {
+	target := newTarget(t)
+	revision := commit(t, target)
+	sum, err := commons.FileSHA256(target.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		policies   []string
+		registry   string
+		repository string
+		explicit   []string
+		want       []string
+	}{
+		{name: "default", want: []string{"users:1.2"}},
+		{name: "version", policies: []string{"version"}, want: []string{"users:1.2"}},
+		{name: "version-sha", policies: []string{"version-sha"}, want: []string{"users:1.2-" + revision[:7]}},
+		{name: "latest", policies: []string{"latest"}, want: []string{"users:latest"}},
+		{name: "digest", policies: []string{"digest"}, want: []string{"users:sha256-" + sum[:12]}},
+		{
+			name:     "all",
+			policies: []string{"version", "latest", "version", "digest"},
+			want:     []string{"users:1.2", "users:latest", "users:sha256-" + sum[:12]},
+		},
+		{
+			name:       "registry",
+			policies:   []string{"version"},
+			registry:   "localhost:5000/",
+			repository: "siscia/effes",
+			want:       []string{"localhost:5000/siscia/effes/users:1.2"},
+		},
+		{
+			name:     "explicit",
+			policies: []string{"latest"},
+			explicit: []string{"ghcr.io/siscia/users:stable", "users:1"},
+			want:     []string{"ghcr.io/siscia/users:stable", "users:1"},
+		},
+	}
+	for _, c := range cases {
+		config := newConfig()
+		config.Tags = c.policies
+		config.Registry = c.registry
+		config.Repository = c.repository
+		engine := &Fake{}
+		report, err := dockerify(target, config, engine, options{tags: c.explicit})
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		if tags := engine.Images[0].Tags; !reflect.DeepEqual(tags, c.want) {
+			t.Errorf("%s: tags %v, want %v", c.name, tags, c.want)
+		}
+		if len(report) != len(c.want) {
+			t.Errorf("%s: %d entries in the report, want %d", c.name, len(report), len(c.want))
+		}
+	}
+
+	config := newConfig()
+	config.Tags = []string{"nightly"}
+	if _, err := dockerify(target, config, &Fake{}, options{}); err == nil {
+		t.Error("an unknown tag policy built an image")
+	}
+}
+
+func TestDockerifyDigestPlatforms(t *testing.T) {
+	digest := func(target target) string {
+		config := newConfig()
+		config.Tags = []string{"digest"}
+		engine := &Fake{}
+		if _, err := dockerify(target, config, engine, options{}); err != nil {
+			t.Fatal(err)
+		}
+		return engine.Images[0].Tags[0]
+	}
+	target := newTarget(t)
+	arm64 := target.path + "-arm64"
+	if err := ioutil.WriteFile(arm64, []byte("not really an arm64 effe"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	amd64Exec := platformExec{platform: "linux/amd64", path: target.path}
+	arm64Exec := platformExec{platform: "linux/arm64", path: arm64}
+
+	single := digest(target)
+	target.platforms = []platformExec{amd64Exec, arm64Exec}
+	multi := digest(target)
+	if multi == single {
+		t.Errorf("the multi-platform image has the digest tag of its first platform: %s", multi)
+	}
+	target.platforms = []platformExec{arm64Exec, amd64Exec}
+	if reordered := digest(target); reordered != multi {
+		t.Errorf("the order of the platforms changed the digest tag: %s, want %s", reordered, multi)
+	}
+	if err := ioutil.WriteFile(arm64, []byte("another arm64 effe"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if changed := digest(target); changed == multi {
+		t.Errorf("a new arm64 executable kept the digest tag %s", changed)
+	}
+}
+
+func TestDockerifyProvenance(t *testing.T) {
+	target := newTarget(t)
+	revision := commit(t, target)
+	engine := &Fake{}
+	if _, err := dockerify(target, newConfig(), engine, options{}); err != nil {
+		t.Fatal(err)
+	}
+	labels := engine.Images[0].Config.Labels
+	if labels[ociLabel+"revision"] != revision {
+		t.Errorf("revision %q, want %q", labels[ociLabel+"revision"], revision)
+	}
+	if created := labels[ociLabel+"created"]; created == "" || created == "1970-01-01T00:00:00Z" {
+		t.Errorf("created %q, want the time of the commit", created)
+	}
+}
+
+func TestDockerifyPush(t *testing.T) {
+	target := newTarget(t)
+	target.platforms = []platformExec{
+		{platform: "linux/amd64", path: target.path},
+		{platform: "linux/arm64", path: target.path + "-arm64"},
+	}
+	config := newConfig()
+	config.Tags = []string{"version", "latest"}
+	engine := &Fake{}
+	report, err := dockerify(target, config, engine, options{push: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(engine.Pushed) != 1 {
+		t.Fatalf("pushed %d images, want 1", len(engine.Pushed))
+	}
+	platforms := engine.Pushed[0].Platforms
+	if len(platforms) != 2 || platforms[1].Platform != "linux/arm64" || platforms[1].Path != target.path+"-arm64" {
+		t.Errorf("platforms %+v", platforms)
+	}
+
+	digest := "sha256:" + strings.Repeat("0", 64)
+	want := []reportEntry{
+		{Path: target.path, Reference: "users:1.2", Platforms: []string{"linux/amd64", "linux/arm64"}, Digest: digest},
+		{Path: target.path, Reference: "users:latest", Platforms: []string{"linux/amd64", "linux/arm64"}, Digest: digest},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("report %+v, want %+v", report, want)
+	}
+}
+
+func TestDockerifySource(t *testing.T) {
+	target := newTarget(t)
+	target.source = "users.go"
+	report, err := dockerify(target, newConfig(), &Fake{}, options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []reportEntry{{Source: "users.go", Reference: "users:1.2"}}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("report %+v, want %+v", report, want)
+	}
+}
+
+func TestDockerifyDryRun(t *testing.T) {
+	engine := &Fake{}
+	report, err := dockerify(newTarget(t), newConfig(), engine, options{dryRun: true, push: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report != nil || len(engine.Images) != 0 || len(engine.Pushed) != 0 {
+		t.Errorf("the dry run built %d and pushed %d images", len(engine.Images), len(engine.Pushed))
+	}
+}
+
+func TestDockerifyEngineError(t *testing.T) {
+	engine := &Fake{Err: errors.New("no daemon")}
+	if _, err := dockerify(newTarget(t), newConfig(), engine, options{push: true}); err == nil || err.Error() != "no daemon" {
+		t.Errorf("error %v, want no daemon", err)
+	}
+	if len(engine.Pushed) != 0 {
+		t.Errorf("pushed %d images after a failed build", len(engine.Pushed))
+	}
+}
@@ -0,0 +1,443 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/siscia/effe-tool/commons"
+	"github.com/siscia/effe-tool/oci"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Image is an image to build.
+type Image struct {
+	// Path is the path of the executable
+	Path string
+	// Tags are the references of the image, as `name:tag`, the
+	// first one is the main one
+	Tags   []string
+	Config commons.DockerConfig
+	// Dockerfile is the rendered Dockerfile, engines that do not use
+	// it ignore it
+	Dockerfile string
+	// Platform is the platform of the executable, as os/arch, empty
+	// when it is the one of the host
+	Platform string
+	// Platforms are the images of every platform, all with the same
+	// tags, when the image is multi-platform; Path is then one of them
+	Platforms []Image
+}
+
+// Engine builds container images.
+type Engine interface {
+	// Name is the name used by --engine.
+	Name() string
+	// Plan returns what --dry-run prints in place of building.
+	Plan(image Image) (string, error)
+	// Build builds the image and returns a description of the result,
+	// a multi-platform image is built for each of its platforms.
+	Build(image Image) (string, error)
+	// Push pushes the image, already built, to every one of its tags,
+	// a multi-platform image as a manifest list.
+	Push(image Image) ([]oci.Pushed, error)
+}
+
+// Engines are the names of the engines, in the order of detection.
+var Engines = []string{"docker", "podman", "buildah", "oci"}
+
+// NewEngine returns the engine called `name`, an empty name detects
+// it; the oci engine writes the images in `output` with `format`.
+func NewEngine(name, output, format string) (Engine, error) {
+	if name == "" {
+		name = DetectEngine()
+	}
+	switch name {
+	case "docker", "podman", "buildah":
+		return cliEngine{command: name}, nil
+	case "oci":
+		return ociEngine{output: output, format: format}, nil
+	}
+	return nil, errors.New("Unknown engine " + name + ", the engines are: " + strings.Join(Engines, ", "))
+}
+
+// DetectEngine returns the first engine available: docker when its
+// daemon answers, then podman and buildah that do not need one, and
+// at last oci that is always available.
+func DetectEngine() string {
+	if _, err := exec.LookPath("docker"); err == nil {
+		if exec.Command("docker", "info").Run() == nil {
+			return "docker"
+		}
+	}
+	for _, name := range []string{"podman", "buildah"} {
+		if _, err := exec.LookPath(name); err == nil {
+			return name
+		}
+	}
+	return "oci"
+}
+
+// cliEngine builds the images with `<command> build`, docker, podman
+// and buildah share the same interface.
+type cliEngine struct {
+	command string
+}
+
+func (e cliEngine) Name() string {
+	return e.command
+}
+
+func (e cliEngine) Plan(image Image) (string, error) {
+	return image.Dockerfile, nil
+}
+
+func (e cliEngine) Build(image Image) (string, error) {
+	if len(image.Platforms) > 0 {
+		return e.buildIndex(image)
+	}
+	args := []string{}
+	for _, tag := range image.Tags {
+		args = append(args, "-t", tag)
+	}
+	if err := e.build(image, args...); err != nil {
+		return "", err
+	}
+	return strings.Join(image.Tags, ", "), nil
+}
+
+// build builds `image` in its own context, with the `args` of build.
+func (e cliEngine) build(image Image, args ...string) (err error) {
+	dir, err := commons.NewWorkspace(commons.DockerWorkspace)
+	if err != nil {
+		return errors.New("Impossible to create the workspace: " + err.Error())
+	}
+	defer func() {
+		commons.CloseWorkspace(dir, err != nil)
+	}()
+
+	if err := commons.NewFile(dir+"/Dockerfile", image.Dockerfile); err != nil {
+		return errors.New("Impossible to create the dockerfile in the workspace " + dir + ": " + err.Error())
+	}
+	if err := commons.StageFile(image.Path, dir+"/exec"); err != nil {
+		return errors.New("Impossible to move the file to the workspace " + dir + ": " + err.Error())
+	}
+	if certs := oci.FindCerts(); certs != "" {
+		if err := commons.StageFile(certs, dir+"/"+certsFile); err != nil {
+			return errors.New("Impossible to move the CA certificates to the workspace " + dir + ": " + err.Error())
+		}
+	}
+	return e.run(append(append([]string{"build"}, args...), dir)...)
+}
+
+// buildIndex builds the image of every platform: podman and buildah
+// gather them in a manifest list named after the first tag, docker
+// tags each one with its platform and creates the list when pushing.
+func (e cliEngine) buildIndex(image Image) (string, error) {
+	platforms := []string{}
+	for _, p := range image.Platforms {
+		platforms = append(platforms, p.Platform)
+	}
+	if e.command == "docker" {
+		built := []string{}
+		for _, p := range image.Platforms {
+			args := []string{"--platform", p.Platform}
+			for _, tag := range image.Tags {
+				args = append(args, "-t", platformTag(tag, p.Platform))
+				built = append(built, platformTag(tag, p.Platform))
+			}
+			if err := e.build(p, args...); err != nil {
+				return "", err
+			}
+		}
+		return strings.Join(built, ", "), nil
+	}
+
+	list := image.Tags[0]
+	// a list left by a previous build would keep its images
+	exec.Command(e.command, "manifest", "rm", list).Run()
+	if err := e.run("manifest", "create", list); err != nil {
+		return "", err
+	}
+	for _, p := range image.Platforms {
+		if err := e.build(p, "--platform", p.Platform, "--manifest", list); err != nil {
+			return "", err
+		}
+	}
+	return list + " for " + strings.Join(platforms, ", "), nil
+}
+
+// platformTag is the tag of the image of a single platform, like
+// effe:1.0-linux-arm64.
+func platformTag(tag, platform string) string {
+	if strings.LastIndex(tag, ":") <= strings.LastIndex(tag, "/") {
+		tag += ":latest"
+	}
+	return tag + "-" + strings.Replace(platform, "/", "-", -1)
+}
+
+// run runs the engine with `args`, showing its output.
+func (e cliEngine) run(args ...string) error {
+	cmd := exec.Command(e.command, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.New("Problem with " + e.command + ": " + err.Error())
+	}
+	return nil
+}
+
+// pushedDigest finds the digest in the output of docker, it is the
+// last one printed by `docker push` and `docker manifest push`.
+var pushedDigest = regexp.MustCompile(`sha256:[0-9a-f]{64}`)
+
+func (e cliEngine) Push(image Image) ([]oci.Pushed, error) {
+	pushed := []oci.Pushed{}
+	for _, tag := range image.Tags {
+		var digest string
+		var err error
+		if len(image.Platforms) > 0 {
+			digest, err = e.pushIndex(image, tag)
+		} else {
+			digest, err = e.push(tag)
+		}
+		if err != nil {
+			return pushed, errors.New("Problem pushing " + tag + " with " + e.command + ": " + err.Error())
+		}
+		pushed = append(pushed, oci.Pushed{Reference: tag, Digest: digest})
+	}
+	return pushed, nil
+}
+
+// push pushes `tag` and returns its digest, docker prints it while
+// podman and buildah write it in --digestfile.
+func (e cliEngine) push(tag string) (string, error) {
+	if e.command == "docker" {
+		return e.printedDigest("push", tag)
+	}
+	return e.digestFile("push", tag)
+}
+
+// pushIndex pushes the images of every platform and their manifest
+// list to `tag`, and returns the digest of the list.
+func (e cliEngine) pushIndex(image Image, tag string) (string, error) {
+	if e.command != "docker" {
+		return e.digestFile("manifest", "push", "--all", image.Tags[0], "docker://"+tag)
+	}
+	// docker creates manifest lists only of images in the registry
+	args := []string{"manifest", "create", tag}
+	for _, p := range image.Platforms {
+		platform := platformTag(tag, p.Platform)
+		if _, err := e.printedDigest("push", platform); err != nil {
+			return "", err
+		}
+		args = append(args, platform)
+	}
+	// a list left by a previous push would be amended, not replaced
+	exec.Command("docker", "manifest", "rm", tag).Run()
+	if err := e.run(args...); err != nil {
+		return "", err
+	}
+	return e.printedDigest("manifest", "push", tag)
+}
+
+// printedDigest runs docker with `args` and returns the digest it
+// prints.
+func (e cliEngine) printedDigest(args ...string) (string, error) {
+	var out bytes.Buffer
+	cmd := exec.Command(e.command, args...)
+	cmd.Stdout = io.MultiWriter(os.Stdout, &out)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	digests := pushedDigest.FindAllString(out.String(), -1)
+	if len(digests) == 0 {
+		return "", errors.New("no digest in the output of " + e.command + " " + args[0])
+	}
+	return digests[len(digests)-1], nil
+}
+
+// digestFile runs podman or buildah with `args`, the first one is the
+// command, and returns the digest written in --digestfile.
+func (e cliEngine) digestFile(args ...string) (string, error) {
+	file, err := ioutil.TempFile(commons.WorkRoot(), "effedigest-")
+	if err != nil {
+		return "", err
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+	// the options go after the command: push or manifest push
+	i := 1
+	if args[0] == "manifest" {
+		i = 2
+	}
+	command := append(append(append([]string{}, args[:i]...), "--digestfile", file.Name()), args[i:]...)
+	cmd := exec.Command(e.command, command...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	digest, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(digest)), nil
+}
+
+// ociEngine assembles the images without a daemon, only scratch
+// images are supported since there is no base image to start from.
+type ociEngine struct {
+	output string
+	format string
+}
+
+func (e ociEngine) Name() string {
+	return "oci"
+}
+
+func (e ociEngine) image(image Image) (oci.Image, error) {
+	config := image.Config
+	if config.Variant != "scratch" || config.BaseImage != "" || config.Dockerfile != "" {
+		return oci.Image{}, errors.New("The oci engine creates only scratch images, without base image or Dockerfile")
+	}
+	return oci.Image{
+		Exec:   image.Path,
+		Tags:   image.Tags,
+		Port:   config.Port,
+		User:   config.User,
+		Env:    config.Env,
+		Labels: config.Labels,
+	}, nil
+}
+
+// images are the images of every platform of `image`.
+func (e ociEngine) images(image Image) ([]oci.Image, error) {
+	images := []oci.Image{}
+	for _, p := range image.Platforms {
+		i, err := e.image(p)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, i)
+	}
+	return images, nil
+}
+
+func (e ociEngine) Plan(image Image) (string, error) {
+	var plan interface{}
+	if len(image.Platforms) > 0 {
+		images, err := e.images(image)
+		if err != nil {
+			return "", err
+		}
+		configs := []oci.ImageConfig{}
+		for _, i := range images {
+			configs = append(configs, oci.ImageConfigOf(i))
+		}
+		plan = configs
+	} else {
+		i, err := e.image(image)
+		if err != nil {
+			return "", err
+		}
+		plan = oci.ImageConfigOf(i)
+	}
+	content, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content) + "\n", nil
+}
+
+func (e ociEngine) Build(image Image) (string, error) {
+	certs := oci.FindCerts()
+	if certs == "" {
+		logError(image.Path, "No CA certificates found, the effe will not be able to make HTTPS calls.")
+	}
+	output := filepath.Join(e.output, strings.NewReplacer(":", "_", "/", "_").Replace(image.Tags[0]))
+	if e.format == oci.FormatArchive {
+		output += ".tar"
+	}
+	var digest string
+	if len(image.Platforms) > 0 {
+		images, err := e.images(image)
+		if err != nil {
+			return "", err
+		}
+		digest, err = oci.WriteIndex(images, certs, e.format, output)
+		if err != nil {
+			return "", errors.New("Impossible to write the image: " + err.Error())
+		}
+	} else {
+		i, err := e.image(image)
+		if err != nil {
+			return "", err
+		}
+		digest, err = oci.Write(i, certs, e.format, output)
+		if err != nil {
+			return "", errors.New("Impossible to write the image: " + err.Error())
+		}
+	}
+	return strings.Join(image.Tags, ", ") + " " + digest + " in " + output, nil
+}
+
+func (e ociEngine) Push(image Image) ([]oci.Pushed, error) {
+	if len(image.Platforms) > 0 {
+		images, err := e.images(image)
+		if err != nil {
+			return nil, err
+		}
+		return oci.PushIndex(images, oci.FindCerts())
+	}
+	i, err := e.image(image)
+	if err != nil {
+		return nil, err
+	}
+	return oci.Push(i, oci.FindCerts())
+}
+
+// Fake is an engine that only records the images, to exercise the
+// docker command without any engine installed.
+type Fake struct {
+	// Images are the images built, in order
+	Images []Image
+	// Pushed are the images pushed, in order
+	Pushed []Image
+	// Err, when set, is returned by every build
+	Err error
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) Plan(image Image) (string, error) {
+	return image.Dockerfile, f.Err
+}
+
+func (f *Fake) Build(image Image) (string, error) {
+	if f.Err != nil {
+		return "", f.Err
+	}
+	f.Images = append(f.Images, image)
+	return strings.Join(image.Tags, ", "), nil
+}
+
+func (f *Fake) Push(image Image) ([]oci.Pushed, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	f.Pushed = append(f.Pushed, image)
+	pushed := []oci.Pushed{}
+	for _, tag := range image.Tags {
+		pushed = append(pushed, oci.Pushed{Reference: tag, Digest: "sha256:" + strings.Repeat("0", 64)})
+	}
+	return pushed, nil
+}
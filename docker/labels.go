@@ -0,0 +1,99 @@
+package docker
+
+import (
+	"encoding/json"
+	"github.com/siscia/effe-tool/commons"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Prefixes of the labels of the images.
+const (
+	ociLabel  = "org.opencontainers.image."
+	effeLabel = "com.github.siscia.effe."
+)
+
+// provenance is where an executable comes from.
+type provenance struct {
+	revision string
+	source   string
+	created  string
+}
+
+// git runs git inside `dir` and returns its output, empty on errors.
+func git(dir string, args ...string) string {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// executableProvenance finds the provenance of an executable in the
+// git repository that contains `origin`, the executable itself or its
+// source.
+// The creation time is the time of the commit, SOURCE_DATE_EPOCH or,
+// at last, the epoch like the config of the image, so that building
+// again the same executable gives the same image.
+func executableProvenance(origin string) provenance {
+	var p provenance
+	dir := filepath.Dir(origin)
+	p.revision = git(dir, "rev-parse", "HEAD")
+	if p.revision != "" {
+		p.created = git(dir, "log", "-1", "--format=%cI")
+	}
+	p.source = git(dir, "config", "--get", "remote.origin.url")
+
+	if p.created == "" {
+		epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64)
+		if err != nil {
+			epoch = 0
+		}
+		p.created = time.Unix(epoch, 0).UTC().Format(time.RFC3339)
+	}
+	return p
+}
+
+// imageLabels returns the labels that describe the effe at `path`:
+// the standard org.opencontainers.image ones and the ones of effe,
+// with the version of the core, the routes, the parameters and the
+// port, so that the effes can be found inspecting the images.
+// `core` is the version of the core the effe is built with, when it
+// is empty the executable is asked for it.
+func imageLabels(path, core string, info commons.Info, p provenance, port int) map[string]string {
+	labels := map[string]string{}
+	set := func(key, value string) {
+		if value != "" {
+			labels[key] = value
+		}
+	}
+	setJSON := func(key string, value interface{}) {
+		if content, err := json.Marshal(value); err == nil {
+			labels[key] = string(content)
+		}
+	}
+
+	set(ociLabel+"title", info.Name)
+	set(ociLabel+"version", info.Version)
+	set(ociLabel+"description", info.Doc)
+	set(ociLabel+"revision", p.revision)
+	set(ociLabel+"source", p.source)
+	set(ociLabel+"created", p.created)
+
+	if core == "" {
+		core, _ = commons.ExecutableCoreVersion(path)
+	}
+	set(effeLabel+"core", core)
+	set(effeLabel+"port", strconv.Itoa(port))
+	if len(info.Routes) > 0 {
+		setJSON(effeLabel+"routes", info.Routes)
+	}
+	if len(info.Params) > 0 {
+		setJSON(effeLabel+"params", info.Params)
+	}
+	return labels
+}
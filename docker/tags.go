@@ -0,0 +1,100 @@
+package docker
+
+import (
+	"errors"
+	"github.com/siscia/effe-tool/commons"
+	"github.com/siscia/effe-tool/naming"
+	"strings"
+)
+
+// TagPolicies are the ways to tag an image:
+// version is the version of the effe, version-sha adds the short git
+// revision, latest is latest and digest is the sha256 of the
+// executables, which changes only when they change.
+var TagPolicies = []string{"version", "version-sha", "latest", "digest"}
+
+// hasPolicy tells if `policy` is one of `policies`.
+func hasPolicy(policies []string, policy string) bool {
+	for _, p := range policies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// imageTags returns the references of the image of `t`, called
+// `name` at `version`: the ones of --tag, if any, or else one for each
+// policy of `config`, under its registry and repository.
+func imageTags(t target, name, version string, p provenance, config commons.DockerConfig, explicit []string) ([]string, error) {
+	warn := func(msg string) {
+		logError(t.origin(), msg)
+	}
+	if len(explicit) > 0 {
+		for _, ref := range explicit {
+			if err := naming.ValidateReference(ref); err != nil {
+				return nil, err
+			}
+		}
+		return explicit, nil
+	}
+
+	repository, err := naming.Repository(name, warn)
+	if err != nil {
+		return nil, err
+	}
+	if version == "" {
+		version = "latest"
+	}
+	for _, prefix := range []string{config.Repository, config.Registry} {
+		if prefix != "" {
+			repository = strings.TrimSuffix(prefix, "/") + "/" + repository
+		}
+	}
+
+	policies := config.Tags
+	if len(policies) == 0 {
+		policies = []string{"version"}
+	}
+	tags := []string{}
+	seen := map[string]bool{}
+	for _, policy := range policies {
+		var tag string
+		switch policy {
+		case "version":
+			tag = version
+		case "version-sha":
+			if len(p.revision) < 7 {
+				warn("No git revision, the version-sha tag is skipped.")
+				continue
+			}
+			tag = version + "-" + p.revision[:7]
+		case "latest":
+			tag = "latest"
+		case "digest":
+			sum, err := t.digest()
+			if err != nil {
+				return nil, err
+			}
+			tag = "sha256-" + sum[:12]
+		default:
+			return nil, errors.New("Unknown tag policy " + policy + ", the policies are: " + strings.Join(TagPolicies, ", "))
+		}
+		if tag, err = naming.Tag(tag, warn); err != nil {
+			return nil, err
+		}
+		ref := repository + ":" + tag
+		// the registry and the repository come from the user
+		if err := naming.ValidateReference(ref); err != nil {
+			return nil, err
+		}
+		if !seen[ref] {
+			seen[ref] = true
+			tags = append(tags, ref)
+		}
+	}
+	if len(tags) == 0 {
+		return nil, errors.New("No tag for the image")
+	}
+	return tags, nil
+}
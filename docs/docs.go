@@ -0,0 +1,244 @@
+package docs
+
+import (
+	"fmt"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/commons"
+	"github.com/siscia/effe-tool/docker"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+func logError(path, msg string) {
+	fmt.Println("File: " + path + " | " + msg)
+}
+
+// entry is an effe of the catalog.
+type entry struct {
+	commons.Info
+	Image  string
+	Source string
+}
+
+// Anchor is the id of the entry inside the catalog.
+func (e entry) Anchor() string {
+	return strings.ToLower(e.Name + "-" + strings.Replace(e.Version, ".", "-", -1))
+}
+
+type catalog struct {
+	Title     string
+	Generated string
+	Entries   []entry
+}
+
+// cell escapes the characters that would break a Markdown table.
+func cell(s string) string {
+	s = strings.Replace(s, "|", "\\|", -1)
+	return strings.Replace(s, "\n", " ", -1)
+}
+
+const markdownCatalog = `# {{.Title}}
+
+Catalog of the effes, generated by effe-tool on {{.Generated}}.
+
+| Name | Version | Image | Source | Doc |
+| --- | --- | --- | --- | --- |
+{{range .Entries}}| [{{cell .Name}}](#{{.Anchor}}) | {{cell .Version}} | ` + "`{{.Image}}`" + ` | [{{.Source}}]({{.Source}}) | {{cell .Doc}} |
+{{end}}{{range .Entries}}
+## {{.Name}} {{.Version}}
+
+{{if .Doc}}{{.Doc}}
+
+{{end}} * Image: ` + "`{{.Image}}`" + `
+ * Source: [{{.Source}}]({{.Source}})
+{{if .Routes}}
+### Routes
+
+| Method | Path | Doc |
+| --- | --- | --- |
+{{range .Routes}}| {{cell .Method}} | ` + "`{{.Path}}`" + ` | {{cell .Doc}} |
+{{end}}{{end}}{{if .Params}}
+### Parameters
+
+| Name | In | Required | Doc |
+| --- | --- | --- | --- |
+{{range .Params}}| ` + "`{{.Name}}`" + ` | {{cell .In}} | {{if .Required}}yes{{else}}no{{end}} | {{cell .Doc}} |
+{{end}}{{end}}{{end}}`
+
+const htmlCatalog = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 60em; margin: 2em auto; padding: 0 1em; color: #222; }
+table { border-collapse: collapse; width: 100%; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+code { background: #f4f4f4; padding: 0 0.2em; }
+section { border-top: 1px solid #ccc; margin-top: 2em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Catalog of the effes, generated by effe-tool on {{.Generated}}.</p>
+<table>
+<tr><th>Name</th><th>Version</th><th>Image</th><th>Source</th><th>Doc</th></tr>
+{{range .Entries}}<tr><td><a href="#{{.Anchor}}">{{.Name}}</a></td><td>{{.Version}}</td><td><code>{{.Image}}</code></td><td><a href="{{.Source}}">{{.Source}}</a></td><td>{{.Doc}}</td></tr>
+{{end}}</table>
+{{range .Entries}}<section id="{{.Anchor}}">
+<h2>{{.Name}} {{.Version}}</h2>
+{{if .Doc}}<p>{{.Doc}}</p>
+{{end}}<ul>
+<li>Image: <code>{{.Image}}</code></li>
+<li>Source: <a href="{{.Source}}">{{.Source}}</a></li>
+</ul>
+{{if .Routes}}<h3>Routes</h3>
+<table>
+<tr><th>Method</th><th>Path</th><th>Doc</th></tr>
+{{range .Routes}}<tr><td>{{.Method}}</td><td><code>{{.Path}}</code></td><td>{{.Doc}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Params}}<h3>Parameters</h3>
+<table>
+<tr><th>Name</th><th>In</th><th>Required</th><th>Doc</th></tr>
+{{range .Params}}<tr><td><code>{{.Name}}</code></td><td>{{.In}}</td><td>{{if .Required}}yes{{else}}no{{end}}</td><td>{{.Doc}}</td></tr>
+{{end}}</table>
+{{end}}</section>
+{{end}}</body>
+</html>
+`
+
+var (
+	markdownTemplate = template.Must(template.New("markdown").Funcs(template.FuncMap{"cell": cell}).Parse(markdownCatalog))
+	htmlTemplate     = htmltemplate.Must(htmltemplate.New("html").Parse(htmlCatalog))
+)
+
+// collect reads the Info of every effe inside `root`, the files
+// that are not effes are skipped.
+// The sources are relative to `dirOut`, so that the links of the
+// catalog work, and the images are named as `docker --from-source`
+// does.
+func collect(root, dirOut string, c *cli.Context) []entry {
+	entries := []entry{}
+	absOut, _ := filepath.Abs(dirOut)
+	ignore := commons.LoadIgnore(root)
+	filepath.Walk(root, func(path string, f os.FileInfo, _ error) error {
+		if f == nil {
+			return nil
+		}
+		if ignore.Match(path) {
+			if f.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !f.Mode().IsRegular() || filepath.Ext(path) != ".go" {
+			return nil
+		}
+		info, err := commons.SourceInfo(path)
+		if err != nil || info.Name == "" {
+			logError(path, "Not an effe, skipped.")
+			return nil
+		}
+		source := path
+		if absPath, err := filepath.Abs(path); err == nil {
+			if relative, err := filepath.Rel(absOut, absPath); err == nil {
+				source = relative
+			}
+		}
+		image := ""
+		if tags, _, err := docker.ImageOf(path, info, c); err == nil {
+			image = tags[0]
+		} else {
+			logError(path, "No image: "+err.Error())
+		}
+		entries = append(entries, entry{info, image, filepath.ToSlash(source)})
+		return nil
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Version < entries[j].Version
+	})
+	return entries
+}
+
+// title is the name of the project in the manifest, or the name of
+// the directory.
+func title(root string) string {
+	if m, err := commons.LoadManifest(root); err == nil && m.Name != "" {
+		return m.Name
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return root
+	}
+	return filepath.Base(abs)
+}
+
+type executor interface {
+	Execute(io.Writer, interface{}) error
+}
+
+func writeCatalog(path string, t executor, c catalog) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := t.Execute(file, c); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Docs is the main entry point, it generates the catalog of every
+// effe inside the directory passed as argument, in Markdown and in
+// HTML.
+func Docs(c *cli.Context) {
+	root := c.Args().First()
+	f, err := os.Stat(root)
+	if err != nil || !f.IsDir() {
+		fmt.Println("Provide the directory of the project as argument.")
+		return
+	}
+	dirOut := c.String("dirout")
+	if err := os.MkdirAll(dirOut, 0777); err != nil {
+		logError(dirOut, "Impossible to create the directory.")
+		fmt.Println(err)
+		return
+	}
+
+	cat := catalog{
+		Title:     title(root),
+		Generated: time.Now().UTC().Format("2006-01-02 15:04 MST"),
+		Entries:   collect(root, dirOut, c),
+	}
+	format := c.String("format")
+	if format == "markdown" || format == "all" {
+		path := filepath.Join(dirOut, "catalog.md")
+		if err := writeCatalog(path, markdownTemplate, cat); err != nil {
+			logError(path, "Impossible to write the catalog.")
+			fmt.Println(err)
+			return
+		}
+		logError(path, "Created.")
+	}
+	if format == "html" || format == "all" {
+		path := filepath.Join(dirOut, "index.html")
+		if err := writeCatalog(path, htmlTemplate, cat); err != nil {
+			logError(path, "Impossible to write the catalog.")
+			fmt.Println(err)
+			return
+		}
+		logError(path, "Created.")
+	}
+	fmt.Printf("Catalog of %d effes created in %s\n", len(cat.Entries), dirOut)
+}
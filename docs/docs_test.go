@@ -0,0 +1,99 @@
+package docs
+
+import (
+	"encoding/json"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/builder"
+	"github.com/siscia/effe-tool/docker"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const helloEffe = `package logic
+
+import (
+	"io"
+	"net/http"
+)
+
+var Info = ` + "`" + `{"name": "hello", "version": "1.0"}` + "`" + `
+
+type Context struct{}
+
+func Init() {}
+
+func Start() (Context, error) { return Context{}, nil }
+
+func Run(ctx Context, err error, w http.ResponseWriter, r *http.Request) error {
+	io.WriteString(w, "Hello")
+	return nil
+}
+
+func Stop(ctx Context) {}
+`
+
+// TestCollectDigest checks that the catalog names the image as
+// `docker --from-source` does with the digest policy, whose tag is
+// the sha256 of the executable and not of the source.
+func TestCollectDigest(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go tool is needed to compile the effe")
+	}
+	root := t.TempDir()
+	source := filepath.Join(root, "hello.go")
+	if err := ioutil.WriteFile(source, []byte(helloEffe), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report := filepath.Join(t.TempDir(), "report.json")
+
+	var entries []entry
+	app := cli.NewApp()
+	app.Commands = []cli.Command{
+		{
+			Name: "docs",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "tag-policy"},
+			},
+			Action: func(c *cli.Context) {
+				entries = collect(root, filepath.Join(root, "catalog"), c)
+			},
+		},
+		{
+			Name: "docker",
+			Flags: append([]cli.Flag{
+				cli.BoolFlag{Name: "from-source"},
+				cli.StringFlag{Name: "engine"},
+				cli.StringFlag{Name: "output"},
+				cli.StringFlag{Name: "oci-format"},
+				cli.StringFlag{Name: "report"},
+				cli.StringFlag{Name: "tag-policy"},
+			}, builder.BuildFlags...),
+			Action: docker.Dockerify,
+		},
+	}
+	app.Run([]string{"effe-tool", "docs", "--tag-policy", "digest", root})
+	app.Run([]string{"effe-tool", "docker", "--from-source", "--engine", "oci", "--output", t.TempDir(), "--oci-format", "layout", "--report", report, "--tag-policy", "digest", source})
+
+	content, err := ioutil.ReadFile(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var images []struct {
+		Reference string `json:"reference"`
+	}
+	if err := json.Unmarshal(content, &images); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || len(images) != 1 {
+		t.Fatalf("%d entries in the catalog and %d images, want 1", len(entries), len(images))
+	}
+	if !strings.HasPrefix(images[0].Reference, "hello:sha256-") {
+		t.Fatalf("docker built %s, want the digest tag", images[0].Reference)
+	}
+	if entries[0].Image != images[0].Reference {
+		t.Errorf("the catalog has %s, docker built %s", entries[0].Image, images[0].Reference)
+	}
+}
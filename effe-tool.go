@@ -3,8 +3,18 @@ package main
 import (
 	"github.com/codegangsta/cli"
 	"github.com/siscia/effe-tool/builder"
+	"github.com/siscia/effe-tool/clean"
+	"github.com/siscia/effe-tool/commons"
+	"github.com/siscia/effe-tool/compose"
 	"github.com/siscia/effe-tool/docker"
+	"github.com/siscia/effe-tool/docs"
 	"github.com/siscia/effe-tool/factory"
+	"github.com/siscia/effe-tool/inspect"
+	"github.com/siscia/effe-tool/invoke"
+	"github.com/siscia/effe-tool/lint"
+	"github.com/siscia/effe-tool/migrate"
+	"github.com/siscia/effe-tool/systemd"
+	"github.com/siscia/effe-tool/tester"
 	"math/rand"
 	"os"
 	"time"
@@ -19,40 +29,440 @@ func main() {
 	app.Usage = "Utility to create, build and use effes."
 	app.Version = "0.2.3"
 
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:   "work-root",
+			Value:  "",
+			Usage:  "Directory where the build workspaces are created, default to the temporary directory.",
+			EnvVar: "EFFE_WORK_ROOT",
+		},
+		cli.BoolFlag{
+			Name:  "keep-work",
+			Usage: "Keep the workspaces of failed builds and print their path.",
+		},
+	}
+	app.Before = func(c *cli.Context) error {
+		commons.SetWorkRoot(c.String("work-root"))
+		commons.SetKeepWork(c.Bool("keep-work"))
+		return nil
+	}
+
 	app.Commands = []cli.Command{
 		{
 			Name:    "new",
 			Aliases: []string{"n"},
 			Usage:   "Create a new empty effe.",
-			Action:  factory.CreateNewEffe,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "template",
+					Value: "hello",
+					Usage: "Template used to create the effe.",
+				},
+				cli.BoolFlag{
+					Name:  "list-templates",
+					Usage: "List the available templates.",
+				},
+				cli.StringFlag{
+					Name:  "name",
+					Value: "",
+					Usage: "Name of the effe, default to the name of the file.",
+				},
+				cli.StringFlag{
+					Name:  "version",
+					Value: "0.1",
+					Usage: "Version of the effe.",
+				},
+				cli.StringFlag{
+					Name:  "doc",
+					Value: "",
+					Usage: "Documentation of the effe, default to the description of the template.",
+				},
+			},
+			Action: factory.CreateNewEffe,
+		},
+		{
+			Name:  "init",
+			Usage: "Create a new effe project in the directory passed as argument.",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "name",
+					Value: "",
+					Usage: "Name of the project and of its first effe, default to the name of the directory.",
+				},
+				cli.StringFlag{
+					Name:  "template",
+					Value: "hello",
+					Usage: "Template used to create the first effe.",
+				},
+				cli.BoolFlag{
+					Name:  "force",
+					Usage: "Overwrite the files already in the directory.",
+				},
+				cli.BoolFlag{
+					Name:  "merge",
+					Usage: "Create only the files missing in the directory.",
+				},
+			},
+			Action: factory.InitProject,
 		},
 		{
 			Name:    "compile",
 			Aliases: []string{"c"},
-			Usage:   "Compile a single file or a whole directory passed as argument.",
-			Flags: []cli.Flag{
+			Usage:   "Compile a single file or a whole directory passed as argument, the sources of the project without it.",
+			Flags: append([]cli.Flag{
 				cli.StringFlag{
 					Name:  "dirout",
 					Value: "out/",
-					Usage: "Directory where to save the executables.",
+					Usage: "Directory where to save the executables, default to the one of effe.json if present.",
 				},
 				cli.StringFlag{
 					Name:  "out",
 					Value: "",
 					Usage: "Custom name to save your executable.",
 				},
+			}, builder.BuildFlags...),
+			Action: builder.Compile,
+		},
+		{
+			Name:    "test",
+			Aliases: []string{"t"},
+			Usage:   "Run the fixtures of a single effe or of every effe in the directory passed as argument, the sources of the project without it.",
+			Flags: append([]cli.Flag{
+				cli.StringFlag{
+					Name:  "fixtures",
+					Value: "fixtures/",
+					Usage: "Directory with the fixtures, the fixtures of foo.go are inside fixtures/foo/; default to the one of effe.json if present.",
+				},
+				cli.StringFlag{
+					Name:  "format",
+					Value: "human",
+					Usage: "Format of the report: human or junit.",
+				},
+				cli.StringFlag{
+					Name:  "output",
+					Value: "",
+					Usage: "File where to write the report, default to the standard output.",
+				},
+			}, builder.BuildFlags...),
+			Action: tester.Test,
+		},
+		{
+			Name:  "migrate",
+			Usage: "Upgrade a single effe or every effe in the directory passed as argument to the current contract.",
+			Flags: []cli.Flag{
 				cli.BoolFlag{
-					Name:  "cgo",
-					Usage: "Set to true to enable cgo.",
+					Name:  "dry-run",
+					Usage: "Show the changes without modifying the files.",
 				},
 			},
-			Action: builder.Compile,
+			Action: migrate.Migrate,
+		},
+		{
+			Name:  "lint",
+			Usage: "Check a single effe or every effe in the directory passed as argument for common mistakes.",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "format",
+					Value: "human",
+					Usage: "Format of the report: human or json.",
+				},
+			},
+			Action: lint.Lint,
+		},
+		{
+			Name:  "info",
+			Usage: "Print the Info of an effe source, executable or docker image.",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "format",
+					Value: "human",
+					Usage: "Format of the output: human or json.",
+				},
+			},
+			Action: inspect.Info,
+		},
+		{
+			Name:  "inspect",
+			Usage: "Print the build information of an executable: Go version, dependencies, cgo, platform, size and hash.",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "format",
+					Value: "human",
+					Usage: "Format of the output: human or json.",
+				},
+			},
+			Action: inspect.Inspect,
+		},
+		{
+			Name:  "docs",
+			Usage: "Generate the catalog of every effe in the directory passed as argument.",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "dirout",
+					Value: "catalog/",
+					Usage: "Directory where to save the catalog.",
+				},
+				cli.StringFlag{
+					Name:  "format",
+					Value: "all",
+					Usage: "Format of the catalog: markdown, html or all.",
+				},
+				cli.StringFlag{
+					Name:  "registry",
+					Value: "",
+					Usage: "Registry of the images, as for docker.",
+				},
+				cli.StringFlag{
+					Name:  "repository",
+					Value: "",
+					Usage: "Repository of the images inside the registry, as for docker.",
+				},
+				cli.StringFlag{
+					Name:  "tag-policy",
+					Value: "",
+					Usage: "Way to tag the images, as for docker, the first tag is used.",
+				},
+			},
+			Action: docs.Docs,
+		},
+		{
+			Name:  "clean",
+			Usage: "Remove the stale workspaces, the directory of the executables and, on request, the cache.",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "dirout",
+					Usage: "Directory of the executables, default to the one of effe.json if present, without both nothing is removed.",
+				},
+				cli.DurationFlag{
+					Name:  "older-than",
+					Value: time.Hour,
+					Usage: "Remove only the workspaces not modified for this long, younger ones may belong to running builds.",
+				},
+				cli.BoolFlag{
+					Name:  "keep-out",
+					Usage: "Do not remove the directory of the executables.",
+				},
+				cli.BoolFlag{
+					Name:  "cache",
+					Usage: "Remove also the cache of effe-tool, the next builds compile every package again.",
+				},
+			},
+			Action: clean.Clean,
 		},
 		{
 			Name:    "docker",
 			Aliases: []string{"d"},
-			Usage:   "Create docker images of a single executable or of every executable in the directory passed as argument.",
-			Action:  docker.Dockerify,
+			Usage:   "Create docker images of a single executable or of every executable in the directory passed as argument, or of sources with --from-source.",
+			Flags: append([]cli.Flag{
+				cli.BoolFlag{
+					Name:  "from-source",
+					Usage: "The argument is a source, or a directory of sources, compiled as static linux executables before building the images.",
+				},
+				cli.StringFlag{
+					Name:  "platform",
+					Value: "",
+					Usage: "Comma separated platforms of the images, like linux/amd64,linux/arm64, with --from-source; the images are gathered in a manifest list.",
+				},
+				cli.StringFlag{
+					Name:  "engine",
+					Value: "",
+					Usage: "Engine that builds the images: docker, podman, buildah or oci, without any daemon; default to the first available.",
+				},
+				cli.StringFlag{
+					Name:  "registry",
+					Value: "",
+					Usage: "Registry of the images, like ghcr.io or localhost:5000.",
+				},
+				cli.StringFlag{
+					Name:  "repository",
+					Value: "",
+					Usage: "Repository of the images inside the registry, like siscia/effes.",
+				},
+				cli.StringSliceFlag{
+					Name:  "tag",
+					Value: &cli.StringSlice{},
+					Usage: "Complete reference of the image, in place of the ones computed from the Info, it can be repeated.",
+				},
+				cli.StringFlag{
+					Name:  "tag-policy",
+					Value: "",
+					Usage: "Comma separated ways to tag the images: version, version-sha, latest and digest; default to version.",
+				},
+				cli.BoolFlag{
+					Name:  "push",
+					Usage: "Push the images to the registry, with the credentials of docker login.",
+				},
+				cli.StringFlag{
+					Name:  "report",
+					Value: "",
+					Usage: "File where to write the images built, with their executables, sources and, once pushed, digests, as JSON.",
+				},
+				cli.StringFlag{
+					Name:  "output",
+					Value: "images/",
+					Usage: "Directory where the oci engine writes the images.",
+				},
+				cli.StringFlag{
+					Name:  "oci-format",
+					Value: "archive",
+					Usage: "Format of the images of the oci engine: archive, a tarball for `docker load`, or layout, an OCI image layout directory.",
+				},
+				cli.StringFlag{
+					Name:  "variant",
+					Value: "scratch",
+					Usage: "Built-in Dockerfile: scratch, distroless or alpine.",
+				},
+				cli.StringFlag{
+					Name:  "base-image",
+					Value: "",
+					Usage: "Image to start from, default to the one of the variant.",
+				},
+				cli.StringFlag{
+					Name:  "dockerfile",
+					Value: "",
+					Usage: "Dockerfile template used in place of the variant.",
+				},
+				cli.IntFlag{
+					Name:  "port",
+					Value: 8080,
+					Usage: "Port where the effe listens inside the container.",
+				},
+				cli.StringFlag{
+					Name:  "user",
+					Value: "",
+					Usage: "User running the effe inside the container, as uid:gid, default to 65532:65532; root to run as root, names need an /etc/passwd, missing in scratch and in the oci images.",
+				},
+				cli.StringSliceFlag{
+					Name:  "env",
+					Value: &cli.StringSlice{},
+					Usage: "Environment variable of the image as `KEY=value`, it can be repeated.",
+				},
+				cli.StringSliceFlag{
+					Name:  "label",
+					Value: &cli.StringSlice{},
+					Usage: "Label of the image as `key=value`, it can be repeated.",
+				},
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Print the Dockerfile, or the image config of the oci engine, without building the image.",
+				},
+			}, builder.BuildFlags...),
+			Action: docker.Dockerify,
+		},
+		{
+			Name:    "invoke",
+			Aliases: []string{"i"},
+			Usage:   "Send a single request to an executable, or to a source file after compiling it, and print the response.",
+			Flags: append([]cli.Flag{
+				cli.StringFlag{
+					Name:  "method",
+					Value: "GET",
+					Usage: "Method of the request.",
+				},
+				cli.StringFlag{
+					Name:  "path",
+					Value: "/",
+					Usage: "Path of the request, query string included.",
+				},
+				cli.StringSliceFlag{
+					Name:  "header",
+					Value: &cli.StringSlice{},
+					Usage: "Header of the request as `Key: Value`, it can be repeated.",
+				},
+				cli.StringFlag{
+					Name:  "data",
+					Value: "",
+					Usage: "Body of the request, use @path to read it from a file.",
+				},
+				cli.BoolFlag{
+					Name:  "oneshot",
+					Usage: "Run the effe in one-shot mode instead of starting the server.",
+				},
+				cli.DurationFlag{
+					Name:  "timeout",
+					Value: 10 * time.Second,
+					Usage: "How long to wait for the effe to be ready and to answer.",
+				},
+			}, builder.BuildFlags...),
+			Action: invoke.Invoke,
+		},
+		{
+			Name:  "systemd",
+			Usage: "Create systemd service and socket units for a single executable or for every executable in the directory passed as argument.",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "dirout",
+					Value: "systemd/",
+					Usage: "Directory where to save the units.",
+				},
+				cli.StringFlag{
+					Name:  "listen",
+					Value: "8080",
+					Usage: "Where the socket listens: a port, an address or the path of a unix socket.",
+				},
+				cli.StringFlag{
+					Name:  "bindir",
+					Value: "",
+					Usage: "Directory where the executables will be installed, default to their current location.",
+				},
+				cli.StringFlag{
+					Name:  "user",
+					Value: "",
+					Usage: "User that runs the effe, default to a dynamic user.",
+				},
+			},
+			Action: systemd.Generate,
+		},
+		{
+			Name:  "compose",
+			Usage: "Create the docker-compose.yml that runs together a single executable or every executable in the directory passed as argument, with the images of the docker command.",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "output",
+					Value: "docker-compose.yml",
+					Usage: "Compose file to write, the proxy configuration is written next to it.",
+				},
+				cli.IntFlag{
+					Name:  "host-port",
+					Value: 8081,
+					Usage: "Port of the host where the first effe is published, the next ones get the following ports.",
+				},
+				cli.BoolFlag{
+					Name:  "proxy",
+					Usage: "Add an nginx service that routes the paths of every effe to its service.",
+				},
+				cli.IntFlag{
+					Name:  "proxy-port",
+					Value: 8080,
+					Usage: "Port of the host where the proxy is published.",
+				},
+				cli.StringFlag{
+					Name:  "proxy-image",
+					Value: "nginx:1.27-alpine",
+					Usage: "Image of the proxy.",
+				},
+				cli.StringFlag{
+					Name:  "registry",
+					Value: "",
+					Usage: "Registry of the images, as for docker.",
+				},
+				cli.StringFlag{
+					Name:  "repository",
+					Value: "",
+					Usage: "Repository of the images inside the registry, as for docker.",
+				},
+				cli.StringFlag{
+					Name:  "tag-policy",
+					Value: "",
+					Usage: "Way to tag the images, as for docker, the first tag is used.",
+				},
+				cli.IntFlag{
+					Name:  "port",
+					Value: 8080,
+					Usage: "Port where the effes listen inside the containers, as for docker.",
+				},
+			},
+			Action: compose.Compose,
 		},
 	}
 
@@ -0,0 +1,478 @@
+//go:build ignore
+
+// The core of every effe, effe-tool embeds it in sources/bindata.go
+// and compiles it, naming the file, together with the logic of the
+// effe as github.com/siscia/effe/logic; the build constraint keeps it
+// out of the packages of effe-tool.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/siscia/effe/logic"
+	"io"
+	"io/ioutil"
+	"log"
+	"log/syslog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unicode/utf8"
+)
+
+// coreVersion identifies this core, effe-tool sets it at build time.
+var coreVersion = "dev"
+
+// provenance is where the executable comes from, the sha256 of the
+// source, the git revision and the version of the core as JSON after
+// a marker; effe-tool sets it at build time and `inspect` finds it in
+// the executable.
+var provenance = ""
+
+// healthPath answers 200 while the effe is serving, it is used by
+// `-probe` and by the healthchecks of the containers.
+const healthPath = "/_effe/health"
+
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, "ok\n")
+}
+
+// probe checks the health of the effe listening on `address`, as
+// passed to -listen, of the local host; it lets the images without
+// curl have a healthcheck.
+// Over TLS the certificate is not verified, the probe only talks to
+// the local host. With mutual TLS it presents `probeCert`, or the
+// certificate of the effe when it is not set: the client CA must have
+// signed it and it must allow client authentication (extended key
+// usage clientAuth), that server certificates often don't.
+// The sockets passed by the parent process or by systemd can't be
+// reached from another process.
+func probe(address, tlsCert, tlsKey, tlsClientCA, probeCert, probeKey string) error {
+	transport := &http.Transport{}
+	host := address
+	switch {
+	case strings.HasPrefix(address, "unix:"):
+		path := strings.TrimPrefix(address, "unix:")
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		}
+		host = "localhost"
+	case strings.HasPrefix(address, "fd:"), address == "systemd":
+		return errors.New("Impossible to probe the socket " + address + " from another process, probe the effe with -port or -listen unix: and tcp:.")
+	default:
+		hostname, port, err := net.SplitHostPort(strings.TrimPrefix(address, "tcp:"))
+		if err != nil {
+			return err
+		}
+		if ip := net.ParseIP(hostname); hostname == "" || (ip != nil && ip.IsUnspecified()) {
+			hostname = "127.0.0.1"
+		}
+		host = net.JoinHostPort(hostname, port)
+	}
+	scheme := "http"
+	if tlsCert != "" {
+		scheme = "https"
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		if tlsClientCA != "" {
+			if probeCert != "" {
+				tlsCert, tlsKey = probeCert, probeKey
+			}
+			certificate, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
+			if err != nil {
+				return err
+			}
+			transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+		}
+	}
+	client := http.Client{Transport: transport, Timeout: 3 * time.Second}
+	resp, err := client.Get(scheme + "://" + host + healthPath)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unhealthy: %s", resp.Status)
+	}
+	return nil
+}
+
+type complexContext struct {
+	ctx logic.Context
+	err error
+}
+
+func generateHandler(pool *sync.Pool, logger *syslog.Writer) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := pool.Get().(complexContext)
+		defer func() {
+			if r := recover(); r != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				logger.Crit("Logic Panicked")
+			}
+		}()
+		err := logic.Run(ctx.ctx, ctx.err, w, r)
+		if err != nil {
+			logger.Debug(err.Error())
+		}
+		if ctx.err == nil {
+			pool.Put(ctx)
+		}
+	}
+}
+
+// certificateReloader keeps the TLS certificate in memory and
+// reads it again from disk every time the process receives a
+// SIGHUP, so that certificates can be rotated without restarting
+// the effe.
+type certificateReloader struct {
+	certPath string
+	keyPath  string
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+}
+
+func newCertificateReloader(certPath, keyPath string) (*certificateReloader, error) {
+	cr := &certificateReloader{certPath: certPath, keyPath: keyPath}
+	if err := cr.reload(); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+func (cr *certificateReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(cr.certPath, cr.keyPath)
+	if err != nil {
+		return err
+	}
+	cr.mu.Lock()
+	cr.cert = &cert
+	cr.mu.Unlock()
+	return nil
+}
+
+// watchSignal reloads the certificate on every SIGHUP, if the new
+// certificate is not valid the old one is kept.
+func (cr *certificateReloader) watchSignal() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if err := cr.reload(); err != nil {
+				log.Println("Impossible to reload the certificate, keeping the old one: " + err.Error())
+				continue
+			}
+			log.Println("Certificate reloaded: " + cr.certPath)
+		}
+	}()
+}
+
+func (cr *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	return cr.cert, nil
+}
+
+// tlsConfig creates the configuration used to serve the effe over
+// TLS, if `clientCA` is not empty the clients are required to
+// present a certificate signed by one of the CAs in the bundle.
+func tlsConfig(cr *certificateReloader, clientCA string) (*tls.Config, error) {
+	config := &tls.Config{
+		GetCertificate: cr.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+	if clientCA == "" {
+		return config, nil
+	}
+	bundle, err := ioutil.ReadFile(clientCA)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New("No valid certificate in the client CA bundle: " + clientCA)
+	}
+	config.ClientCAs = pool
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+	return config, nil
+}
+
+// systemdFirstFd is the first file descriptor used by systemd
+// to pass sockets to the activated service.
+const systemdFirstFd = 3
+
+// listen creates the listener described by `address`, the accepted
+// formats are `unix:/path/to.sock`, `tcp:host:port`, `fd:N` and
+// `systemd`, which uses the first socket passed by systemd with
+// socket activation.
+// An address without prefix is considered a TCP address.
+func listen(address string) (net.Listener, error) {
+	switch {
+	case strings.HasPrefix(address, "unix:"):
+		path := strings.TrimPrefix(address, "unix:")
+		// removing the socket left behind by a previous run
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return net.Listen("unix", path)
+	case strings.HasPrefix(address, "tcp:"):
+		return net.Listen("tcp", strings.TrimPrefix(address, "tcp:"))
+	case strings.HasPrefix(address, "fd:"):
+		fd, err := strconv.Atoi(strings.TrimPrefix(address, "fd:"))
+		if err != nil {
+			return nil, errors.New("Invalid file descriptor: " + address)
+		}
+		return fileListener(fd)
+	case address == "systemd":
+		return systemdListener()
+	}
+	return net.Listen("tcp", address)
+}
+
+// systemdListener returns the first socket passed by systemd,
+// following the LISTEN_PID/LISTEN_FDS protocol.
+func systemdListener() (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, errors.New("No socket passed by systemd, LISTEN_PID is not set for this process.")
+	}
+	fds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || fds < 1 {
+		return nil, errors.New("No socket passed by systemd, LISTEN_FDS is not set.")
+	}
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+	return fileListener(systemdFirstFd)
+}
+
+func fileListener(fd int) (net.Listener, error) {
+	f := os.NewFile(uintptr(fd), "fd:"+strconv.Itoa(fd))
+	defer f.Close()
+	return net.FileListener(f)
+}
+
+// invocationRequest is the request read in one-shot mode, the
+// Request of the commons of effe-tool.
+// The body is plain text unless `isBase64Encoded` is set.
+type invocationRequest struct {
+	Method          string              `json:"method"`
+	Path            string              `json:"path"`
+	Headers         map[string][]string `json:"headers"`
+	Body            string              `json:"body"`
+	IsBase64Encoded bool                `json:"isBase64Encoded"`
+}
+
+// invocationResponse is the response written in one-shot mode, the
+// Response of the commons of effe-tool.
+// The body is encoded in base64 only when it is not valid UTF-8.
+type invocationResponse struct {
+	Status          int                 `json:"status"`
+	Headers         map[string][]string `json:"headers"`
+	Body            string              `json:"body"`
+	IsBase64Encoded bool                `json:"isBase64Encoded"`
+}
+
+func (ir invocationRequest) httpRequest() (*http.Request, error) {
+	body := []byte(ir.Body)
+	if ir.IsBase64Encoded {
+		var err error
+		if body, err = base64.StdEncoding.DecodeString(ir.Body); err != nil {
+			return nil, err
+		}
+	}
+	if ir.Method == "" {
+		ir.Method = "GET"
+	}
+	if ir.Path == "" {
+		ir.Path = "/"
+	}
+	r, err := http.NewRequest(ir.Method, ir.Path, strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	r.RequestURI = ir.Path
+	r.RemoteAddr = "127.0.0.1:0"
+	for key, values := range ir.Headers {
+		for _, value := range values {
+			r.Header.Add(key, value)
+		}
+	}
+	r.Host = r.Header.Get("Host")
+	return r, nil
+}
+
+// invoke runs the effe exactly once: it reads the request from `source`
+// (a path or `-` for the standard input), runs Init, Start, Run and
+// Stop and writes the response on the standard output.
+// Everything the logic prints is redirected to the standard error so
+// that the standard output contains only the response.
+func invoke(source string) error {
+	var input io.Reader = os.Stdin
+	if source != "-" {
+		file, err := os.Open(source)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	var ir invocationRequest
+	if err := json.NewDecoder(input).Decode(&ir); err != nil {
+		return errors.New("Impossible to read the request: " + err.Error())
+	}
+	r, err := ir.httpRequest()
+	if err != nil {
+		return errors.New("Invalid request: " + err.Error())
+	}
+
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = stdout }()
+
+	w := httptest.NewRecorder()
+	logic.Init()
+	ctx, startErr := logic.Start()
+	runErr := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				err = fmt.Errorf("Logic Panicked: %v", r)
+			}
+		}()
+		return logic.Run(ctx, startErr, w, r)
+	}()
+	if startErr == nil {
+		logic.Stop(ctx)
+	}
+
+	result := w.Result()
+	body, _ := ioutil.ReadAll(result.Body)
+	response := invocationResponse{
+		Status:  result.StatusCode,
+		Headers: result.Header,
+		Body:    string(body),
+	}
+	if !utf8.Valid(body) {
+		response.Body = base64.StdEncoding.EncodeToString(body)
+		response.IsBase64Encoded = true
+	}
+	if err := json.NewEncoder(stdout).Encode(response); err != nil {
+		return err
+	}
+	return runErr
+}
+
+func main() {
+	port := flag.Int("port", 8080, "Port where serve the effe.")
+	// the healthcheck of a container runs the executable without the
+	// arguments of the effe, the environment tells it where to probe
+	address := flag.String("listen", os.Getenv("EFFE_LISTEN"), "Where to listen: unix:/path, tcp:host:port, fd:N or systemd. Overrides -port. Default to EFFE_LISTEN.")
+	info := flag.Bool("info", false, "Print the effe information, then exit.")
+	printCoreVersion := flag.Bool("core-version", false, "Print the version of the core, then exit.")
+	printProvenance := flag.Bool("provenance", false, "Print where the executable comes from, as JSON, then exit.")
+	probeHealth := flag.Bool("probe", false, "Check the health of the effe listening on -port or -listen, over TLS with -tls-cert, then exit with 0 if it is healthy.")
+	oneShot := flag.String("invoke", "", "Run the effe once on the JSON request read from the file, - for the standard input, then exit.")
+	tlsCert := flag.String("tls-cert", os.Getenv("EFFE_TLS_CERT"), "Certificate used to serve the effe over TLS, reloaded on SIGHUP. Default to EFFE_TLS_CERT.")
+	tlsKey := flag.String("tls-key", os.Getenv("EFFE_TLS_KEY"), "Private key of the TLS certificate. Default to EFFE_TLS_KEY.")
+	tlsClientCA := flag.String("tls-client-ca", os.Getenv("EFFE_TLS_CLIENT_CA"), "CA bundle used to verify client certificates, enables mutual TLS. Default to EFFE_TLS_CLIENT_CA.")
+	probeCert := flag.String("probe-tls-cert", os.Getenv("EFFE_PROBE_TLS_CERT"), "Client certificate presented by -probe under mutual TLS, default to the one of -tls-cert. Default to EFFE_PROBE_TLS_CERT.")
+	probeKey := flag.String("probe-tls-key", os.Getenv("EFFE_PROBE_TLS_KEY"), "Private key of the client certificate of -probe. Default to EFFE_PROBE_TLS_KEY.")
+	h2c := flag.Bool("h2c", false, "Serve HTTP/2 over cleartext connections (h2c), useful behind proxies.")
+	readTimeout := flag.Duration("read-timeout", 0, "Maximum duration for reading the entire request, 0 means no timeout.")
+	readHeaderTimeout := flag.Duration("read-header-timeout", 0, "Maximum duration for reading the request headers, 0 means no timeout.")
+	writeTimeout := flag.Duration("write-timeout", 0, "Maximum duration before timing out the write of the response, 0 means no timeout.")
+	idleTimeout := flag.Duration("idle-timeout", 0, "Maximum duration to wait for the next request on keep-alive connections.")
+	maxHeaderBytes := flag.Int("max-header-bytes", http.DefaultMaxHeaderBytes, "Maximum size of the request headers.")
+	flag.Parse()
+	if *info {
+		fmt.Println(logic.Info)
+		return
+	}
+	if *printCoreVersion {
+		fmt.Println(coreVersion)
+		return
+	}
+	if *printProvenance {
+		fmt.Println(strings.TrimPrefix(provenance, "effe-provenance:"))
+		return
+	}
+	if *address == "" {
+		*address = fmt.Sprintf(":%d", *port)
+	}
+	if *probeHealth {
+		if (*probeCert == "") != (*probeKey == "") {
+			log.Fatal("Both -probe-tls-cert and -probe-tls-key must be provided.")
+		}
+		if err := probe(*address, *tlsCert, *tlsKey, *tlsClientCA, *probeCert, *probeKey); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if *oneShot != "" {
+		if err := invoke(*oneShot); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if (*tlsCert == "") != (*tlsKey == "") {
+		log.Fatal("Both -tls-cert and -tls-key must be provided to serve over TLS.")
+	}
+	if *tlsClientCA != "" && *tlsCert == "" {
+		log.Fatal("-tls-client-ca requires -tls-cert and -tls-key.")
+	}
+	listener, err := listen(*address)
+	if err != nil {
+		log.Fatal(err)
+	}
+	logic.Init()
+	logger, _ := syslog.New(syslog.LOG_ERR|syslog.LOG_USER, "Logs From Effe ")
+	var ctxPool = &sync.Pool{New: func() interface{} {
+		ctx, err := logic.Start()
+		return complexContext{ctx, err}
+	}}
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthPath, healthHandler)
+	mux.HandleFunc("/", generateHandler(ctxPool, logger))
+	server := &http.Server{
+		Handler:           mux,
+		ReadTimeout:       *readTimeout,
+		ReadHeaderTimeout: *readHeaderTimeout,
+		WriteTimeout:      *writeTimeout,
+		IdleTimeout:       *idleTimeout,
+		MaxHeaderBytes:    *maxHeaderBytes,
+	}
+	if *h2c {
+		server.Protocols = new(http.Protocols)
+		server.Protocols.SetHTTP1(true)
+		server.Protocols.SetHTTP2(true)
+		server.Protocols.SetUnencryptedHTTP2(true)
+	}
+	if *tlsCert == "" {
+		log.Fatal(server.Serve(listener))
+	}
+	reloader, err := newCertificateReloader(*tlsCert, *tlsKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	reloader.watchSignal()
+	if server.TLSConfig, err = tlsConfig(reloader, *tlsClientCA); err != nil {
+		log.Fatal(err)
+	}
+	log.Fatal(server.ServeTLS(listener, "", ""))
+}
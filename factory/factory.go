@@ -1,19 +1,201 @@
 package factory
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/codegangsta/cli"
 	"github.com/siscia/effe-tool/commons"
 	"github.com/siscia/effe-tool/sources"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
 )
 
+// builtinTemplates are the shapes of effe shipped with effe-tool,
+// the source of each template is in assets/templates/<name>.go.tmpl
+var builtinTemplates = []struct {
+	name string
+	doc  string
+}{
+	{"hello", "Hello world, the introductory example."},
+	{"json_api", "JSON API endpoint, GET returns an item and POST validates one."},
+	{"reverse_proxy", "Reverse proxy forwarding every request to EFFE_UPSTREAM."},
+	{"form", "HTML form handler with validation."},
+	{"static", "Static responder serving fixed content for a set of paths."},
+	{"webhook", "Webhook receiver verifying HMAC-SHA256 signatures."},
+	{"scheduled", "Scheduled job triggered by cron or by a scheduler."},
+}
+
+const templateExt = ".go.tmpl"
+
+// effeTemplate is a template that `new` can use, `origin` is
+// either `built-in` or the path of the user template.
+type effeTemplate struct {
+	name   string
+	doc    string
+	origin string
+}
+
+func (t effeTemplate) source() (string, error) {
+	if t.origin == "built-in" {
+		return sources.Template(t.name)
+	}
+	source, err := ioutil.ReadFile(t.origin)
+	return string(source), err
+}
+
+// templateData are the variables available inside the templates:
+// {{.Name}}, {{.Version}} and {{.Doc}}.
+type templateData struct {
+	Name    string
+	Version string
+	Doc     string
+}
+
+// templateDirs returns the directories where to look for user
+// templates, the project-local directory comes first: it is inside
+// the root of the project, found from the current directory, or else
+// inside the current directory.
+func templateDirs() []string {
+	root := "."
+	if dir, _, err := commons.FindManifest(commons.ManifestFile); err == nil {
+		root = dir
+	}
+	dirs := []string{filepath.Join(root, ".effe", "templates")}
+	config := os.Getenv("XDG_CONFIG_HOME")
+	if config == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			config = filepath.Join(home, ".config")
+		}
+	}
+	if config != "" {
+		dirs = append(dirs, filepath.Join(config, "effe-tool", "templates"))
+	}
+	return dirs
+}
+
+// findTemplates returns all the available templates, a user
+// template hides any template with the same name that comes after
+// it: project-local, then user, then built-in.
+func findTemplates() []effeTemplate {
+	seen := map[string]bool{}
+	found := []effeTemplate{}
+	for _, dir := range templateDirs() {
+		paths, _ := filepath.Glob(filepath.Join(dir, "*"+templateExt))
+		for _, path := range paths {
+			name := strings.TrimSuffix(filepath.Base(path), templateExt)
+			if !seen[name] {
+				seen[name] = true
+				found = append(found, effeTemplate{name: name, origin: path})
+			}
+		}
+	}
+	for _, t := range builtinTemplates {
+		if !seen[t.name] {
+			seen[t.name] = true
+			found = append(found, effeTemplate{name: t.name, doc: t.doc, origin: "built-in"})
+		}
+	}
+	return found
+}
+
+func findTemplate(name string) (effeTemplate, bool) {
+	for _, t := range findTemplates() {
+		if t.name == name {
+			return t, true
+		}
+	}
+	return effeTemplate{}, false
+}
+
+func listTemplates() {
+	for _, t := range findTemplates() {
+		doc := t.doc
+		if t.origin != "built-in" {
+			doc = t.origin
+		}
+		fmt.Printf("%-15s %s\n", t.name, doc)
+	}
+}
+
+// jsonString quotes `s` so that it can be placed inside Info,
+// which is a JSON document inside a Go raw string.
+func jsonString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return strings.Replace(string(quoted), "`", "\\u0060", -1)
+}
+
+// render executes the template and checks that the result is
+// valid Go code, the result is also formatted.
+func render(t effeTemplate, data templateData) (string, error) {
+	source, err := t.source()
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New(t.name).Funcs(template.FuncMap{"json": jsonString}).Parse(source)
+	if err != nil {
+		return "", err
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", err
+	}
+	formatted, err := format.Source(rendered.Bytes())
+	if err != nil {
+		return "", errors.New("The template " + t.name + " does not produce valid Go code: " + err.Error())
+	}
+	return string(formatted), nil
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// nameFromFilename creates the default name of an effe from the
+// name of its file, `hello-world.go` becomes `hello_world`.
+func nameFromFilename(filename string) string {
+	base := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	return strings.Trim(invalidNameChars.ReplaceAllString(base, "_"), "_")
+}
+
+// newEffeSource renders the template `templateName` for the effe
+// saved as `filename`, empty name and doc get a default value.
+func newEffeSource(templateName, filename, name, version, doc string) (string, error) {
+	t, ok := findTemplate(templateName)
+	if !ok {
+		return "", errors.New("Template not found: " + templateName)
+	}
+	if name == "" {
+		name = nameFromFilename(filename)
+	}
+	if doc == "" {
+		doc = t.doc
+	}
+	return render(t, templateData{Name: name, Version: version, Doc: doc})
+}
+
 func CreateNewEffe(c *cli.Context) {
+	if c.Bool("list-templates") {
+		listTemplates()
+		return
+	}
 	filename := c.Args().First()
 	if filename == "" {
 		fmt.Println("Provide an argument as filename for the effe.")
 		return
 	}
-	if err := commons.NewFile(filename, sources.Logic); err != nil {
+	source, err := newEffeSource(c.String("template"), filename, c.String("name"), c.String("version"), c.String("doc"))
+	if err != nil {
+		fmt.Println(err)
+		fmt.Println("Available templates are:")
+		listTemplates()
+		return
+	}
+	if err := commons.NewFile(filename, source); err != nil {
 		fmt.Println(err)
 	} else {
 		fmt.Println("Successfully created the new effe, path: " + filename)
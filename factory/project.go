@@ -0,0 +1,158 @@
+package factory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/commons"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const defaultIgnore = `# Files and directories skipped by effe-tool when walking the project.
+go.mod
+go.sum
+*.json
+*.md
+*_test.go
+fixtures
+out
+`
+
+// goMod makes the project a module so that editors resolve the
+// logic package of every effe, effe-tool itself does not use it.
+func goMod(name string) string {
+	return "module " + name + "\n\ngo 1.24\n"
+}
+
+// firstFixture is the fixture of the first effe, it works with the
+// hello template.
+const firstFixture = `{
+	"name": "says hello",
+	"request": {
+		"method": "GET",
+		"path": "/"
+	},
+	"expect": {
+		"status": 200,
+		"bodyRegex": "^Hello from Effe"
+	}
+}
+`
+
+// projectFile is a file to create inside the project.
+type projectFile struct {
+	path    string
+	content string
+}
+
+// isEmptyDir returns true if `dir` does not exist or is empty.
+func isEmptyDir(dir string) (bool, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	return len(entries) == 0, err
+}
+
+// scaffold lists every file of a new project called `name` whose
+// first effe is created from the template `templateName`.
+// Every effe lives in its own directory so that each directory is a
+// single logic package.
+func scaffold(name, templateName string) ([]projectFile, error) {
+	manifest := commons.Manifest{
+		Name:     name,
+		Sources:  "src",
+		Out:      "out",
+		Fixtures: "fixtures",
+		Build:    commons.BuildConfig{Profile: "release"},
+		Docker:   commons.DockerConfig{Variant: "scratch", Port: 8080},
+	}
+	manifestContent, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	effePath := filepath.Join(manifest.Sources, name, name+".go")
+	source, err := newEffeSource(templateName, effePath, name, "0.1", "")
+	if err != nil {
+		return nil, err
+	}
+	files := []projectFile{
+		{commons.ManifestFile, string(manifestContent) + "\n"},
+		{"go.mod", goMod(name)},
+		{commons.IgnoreFile, defaultIgnore},
+		{effePath, source},
+	}
+	if templateName == "hello" {
+		files = append(files, projectFile{filepath.Join(manifest.Fixtures, name, "hello.json"), firstFixture})
+	}
+	return files, nil
+}
+
+// InitProject creates a new effe project inside the directory passed
+// as argument.
+// The directory must be empty, unless `--force` is passed, which
+// overwrites existing files, or `--merge` is passed, which creates
+// only the missing files.
+func InitProject(c *cli.Context) {
+	dir := c.Args().First()
+	if dir == "" {
+		fmt.Println("Provide an argument as directory for the project.")
+		return
+	}
+	force, merge := c.Bool("force"), c.Bool("merge")
+	if force && merge {
+		fmt.Println("Use either --force or --merge, not both.")
+		return
+	}
+	empty, err := isEmptyDir(dir)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if !empty && !force && !merge {
+		fmt.Println("The directory " + dir + " is not empty, use --force to overwrite or --merge to add only the missing files.")
+		return
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	name := c.String("name")
+	if name == "" {
+		name = nameFromFilename(absDir)
+	}
+	if name == "" {
+		fmt.Println(errors.New("Impossible to derive a name from " + dir + ", use --name."))
+		return
+	}
+	files, err := scaffold(name, c.String("template"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, file := range files {
+		path := filepath.Join(dir, file.path)
+		if _, err := os.Stat(path); err == nil && !force {
+			fmt.Println("File: " + path + " | Already exists, skipped.")
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			fmt.Println("File: " + path + " | Impossible to create the directory.")
+			fmt.Println(err)
+			return
+		}
+		if err := ioutil.WriteFile(path, []byte(file.content), 0644); err != nil {
+			fmt.Println("File: " + path + " | Impossible to create the file.")
+			fmt.Println(err)
+			return
+		}
+		fmt.Println("File: " + path + " | Created.")
+	}
+	fmt.Println("Successfully created the new project, path: " + dir)
+}
@@ -0,0 +1,298 @@
+package inspect
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"debug/elf"
+	"encoding/json"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/commons"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func logError(path, msg string) {
+	fmt.Println("File: " + path + " | " + msg)
+}
+
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// readInfo reads the Info of `target`: a source file is parsed, an
+// executable is run with `-info` and anything else is considered a
+// docker image.
+func readInfo(target string) (commons.Info, error) {
+	if filepath.Ext(target) == ".go" {
+		return commons.SourceInfo(target)
+	}
+	if f, err := os.Stat(target); err == nil && f.Mode().IsRegular() {
+		return commons.ExecutableInfo(target)
+	}
+	return commons.ImageInfo(target)
+}
+
+func printInfo(i commons.Info) {
+	fmt.Println("Name:    " + i.Name)
+	fmt.Println("Version: " + i.Version)
+	fmt.Println("Doc:     " + i.Doc)
+	if len(i.Routes) > 0 {
+		fmt.Println("Routes:")
+		for _, r := range i.Routes {
+			fmt.Printf("  %-7s %-20s %s\n", r.Method, r.Path, r.Doc)
+		}
+	}
+	if len(i.Params) > 0 {
+		fmt.Println("Params:")
+		for _, p := range i.Params {
+			where := p.In
+			if p.Required {
+				where += ", required"
+			}
+			fmt.Printf("  %-20s %-20s %s\n", p.Name, "("+where+")", p.Doc)
+		}
+	}
+}
+
+// Info prints the Info of the effe source, executable or docker
+// image passed as argument.
+func Info(c *cli.Context) {
+	target := c.Args().First()
+	if target == "" {
+		fmt.Println("Provide a source file, an executable or a docker image as argument.")
+		return
+	}
+	i, err := readInfo(target)
+	if err != nil {
+		logError(target, "Impossible to read the Info.")
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if c.String("format") == "json" {
+		if err := printJSON(i); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	printInfo(i)
+}
+
+type dependency struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Sum     string `json:"sum,omitempty"`
+	Replace string `json:"replace,omitempty"`
+}
+
+type setting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// facts are what can be known about an executable without running
+// it, provenance holds the sha256 of the source, the git revision and
+// the version of the core embedded by effe-tool.
+type facts struct {
+	Path         string       `json:"path"`
+	Size         int64        `json:"size"`
+	SHA256       string       `json:"sha256"`
+	GoVersion    string       `json:"goVersion"`
+	Platform     string       `json:"platform"`
+	Cgo          bool         `json:"cgo"`
+	Dynamic      bool         `json:"dynamic"`
+	Libraries    []string     `json:"libraries,omitempty"`
+	Stripped     bool         `json:"stripped"`
+	Main         string       `json:"main"`
+	Dependencies []dependency `json:"dependencies"`
+	Settings     []setting    `json:"settings"`
+	Provenance   []setting    `json:"provenance"`
+}
+
+// elfArch maps the ELF machines to GOARCH, for the executables
+// without build settings.
+var elfArch = map[elf.Machine]string{
+	elf.EM_386:     "386",
+	elf.EM_X86_64:  "amd64",
+	elf.EM_ARM:     "arm",
+	elf.EM_AARCH64: "arm64",
+	elf.EM_PPC64:   "ppc64",
+	elf.EM_S390:    "s390x",
+	elf.EM_RISCV:   "riscv64",
+	elf.EM_MIPS:    "mips",
+}
+
+// readELF fills the facts known only to the ELF headers, the
+// executables for other systems are skipped.
+func readELF(path string, f *facts) error {
+	file, err := elf.Open(path)
+	if err != nil {
+		if _, ok := err.(*elf.FormatError); ok {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+	f.Stripped = file.Section(".symtab") == nil
+	for _, p := range file.Progs {
+		if p.Type == elf.PT_INTERP {
+			f.Dynamic = true
+		}
+	}
+	if libraries, err := file.ImportedLibraries(); err == nil {
+		f.Libraries = libraries
+	}
+	if f.Platform == "" {
+		if arch, ok := elfArch[file.Machine]; ok {
+			f.Platform = "linux/" + arch
+		} else {
+			f.Platform = "linux/" + file.Machine.String()
+		}
+	}
+	return nil
+}
+
+// readProvenance finds the provenance embedded in the executable at
+// `path`; the marker may appear elsewhere, as in the core itself, so
+// every occurrence is tried until one is followed by a provenance.
+func readProvenance(path string) (commons.Provenance, bool, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return commons.Provenance{}, false, err
+	}
+	marker := []byte(commons.ProvenanceMarker)
+	for i := bytes.Index(content, marker); i >= 0; {
+		content = content[i+len(marker):]
+		var p commons.Provenance
+		if json.NewDecoder(bytes.NewReader(content)).Decode(&p) == nil && p.Source != "" {
+			return p, true, nil
+		}
+		i = bytes.Index(content, marker)
+	}
+	return commons.Provenance{}, false, nil
+}
+
+func inspectExecutable(path string) (facts, error) {
+	f := facts{Path: path, Dependencies: []dependency{}, Settings: []setting{}, Provenance: []setting{}}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return f, err
+	}
+	f.Size = stat.Size()
+	if f.SHA256, err = commons.FileSHA256(path); err != nil {
+		return f, err
+	}
+
+	build, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return f, err
+	}
+	f.GoVersion = build.GoVersion
+	f.Main = build.Path
+	for _, dep := range build.Deps {
+		d := dependency{Path: dep.Path, Version: dep.Version, Sum: dep.Sum}
+		if dep.Replace != nil {
+			d.Replace = dep.Replace.Path + " " + dep.Replace.Version
+		}
+		f.Dependencies = append(f.Dependencies, d)
+	}
+	goos, goarch := "", ""
+	for _, s := range build.Settings {
+		switch {
+		case s.Key == "GOOS":
+			goos = s.Value
+		case s.Key == "GOARCH":
+			goarch = s.Value
+		case s.Key == "CGO_ENABLED":
+			f.Cgo = s.Value == "1"
+		}
+		f.Settings = append(f.Settings, setting{s.Key, s.Value})
+	}
+	if goos != "" && goarch != "" {
+		f.Platform = goos + "/" + goarch
+	}
+
+	p, ok, err := readProvenance(path)
+	if err != nil {
+		return f, err
+	}
+	if ok {
+		f.Provenance = append(f.Provenance, setting{"source", p.Source})
+		if p.Revision != "" {
+			f.Provenance = append(f.Provenance, setting{"revision", p.Revision})
+		}
+		f.Provenance = append(f.Provenance, setting{"core", p.Core})
+	}
+	return f, readELF(path, &f)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func printFacts(f facts) {
+	linkage := "statically linked"
+	if f.Dynamic {
+		linkage = "dynamically linked"
+	}
+	fmt.Println("File:       " + f.Path)
+	fmt.Printf("Size:       %d bytes\n", f.Size)
+	fmt.Println("SHA256:     " + f.SHA256)
+	fmt.Println("Go version: " + f.GoVersion)
+	fmt.Println("Platform:   " + f.Platform)
+	fmt.Println("Cgo:        " + yesNo(f.Cgo) + ", " + linkage)
+	for _, l := range f.Libraries {
+		fmt.Println("            " + l)
+	}
+	fmt.Println("Stripped:   " + yesNo(f.Stripped))
+	fmt.Println("Main:       " + f.Main)
+	if len(f.Dependencies) > 0 {
+		fmt.Println("Dependencies:")
+		for _, d := range f.Dependencies {
+			if d.Replace != "" {
+				fmt.Println("  " + d.Path + " " + d.Version + " => " + d.Replace)
+			} else {
+				fmt.Println("  " + d.Path + " " + d.Version)
+			}
+		}
+	}
+	if len(f.Settings) > 0 {
+		fmt.Println("Build settings:")
+		for _, s := range f.Settings {
+			fmt.Printf("  %-14s %s\n", s.Key, s.Value)
+		}
+	}
+	if len(f.Provenance) > 0 {
+		fmt.Println("Provenance:")
+		for _, s := range f.Provenance {
+			fmt.Printf("  %-14s %s\n", s.Key, s.Value)
+		}
+	}
+}
+
+// Inspect prints what is known about the executable passed as
+// argument, reading its build information and its headers.
+func Inspect(c *cli.Context) {
+	path := c.Args().First()
+	f, err := inspectExecutable(path)
+	if err != nil {
+		logError(path, "Impossible to inspect the executable.")
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if c.String("format") == "json" {
+		if err := printJSON(f); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	printFacts(f)
+}
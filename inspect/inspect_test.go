@@ -0,0 +1,104 @@
+package inspect
+
+import (
+	"github.com/siscia/effe-tool/builder"
+	"github.com/siscia/effe-tool/commons"
+	"github.com/siscia/effe-tool/sources"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const helloEffe = `package logic
+
+import (
+	"io"
+	"net/http"
+)
+
+var Info = ` + "`" + `{"name": "hello", "version": "1.0"}` + "`" + `
+
+type Context struct{}
+
+func Init() {}
+
+func Start() (Context, error) { return Context{}, nil }
+
+func Run(ctx Context, err error, w http.ResponseWriter, r *http.Request) error {
+	io.WriteString(w, "Hello")
+	return nil
+}
+
+func Stop(ctx Context) {}
+`
+
+func TestReadProvenance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exec")
+	content := "\x00" + commons.ProvenanceMarker + "not json\x00" +
+		commons.ProvenanceMarker + `{"source":"8f7d58b9","revision":"929c7191","core":"9dfe32226377"}` + "trailing\x00"
+	if err := ioutil.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+	p, ok, err := readProvenance(path)
+	want := commons.Provenance{Source: "8f7d58b9", Revision: "929c7191", Core: "9dfe32226377"}
+	if err != nil || !ok || p != want {
+		t.Errorf("provenance %+v, %v, %v, want %+v", p, ok, err, want)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("\x00"+commons.ProvenanceMarker+"\x00"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := readProvenance(path); ok || err != nil {
+		t.Errorf("found a provenance in a marker alone: %v, %v", ok, err)
+	}
+}
+
+// TestInspectProvenance compiles an effe inside a git repository and
+// reads back what the builder embedded, from the stripped executable
+// of the release profile.
+func TestInspectProvenance(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go tool is needed to compile the effe")
+	}
+	dir := t.TempDir()
+	source := filepath.Join(dir, "hello.go")
+	if err := ioutil.WriteFile(source, []byte(helloEffe), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=effe", "-c", "user.email=effe@example.com", "commit", "-q", "-m", "hello"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Skip("git is not usable: " + string(out))
+		}
+	}
+	sum, err := commons.FileSHA256(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	execPath, err := builder.CompileSingleFile(source, builder.Options{Profile: builder.DefaultProfile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer commons.CloseWorkspace(filepath.Dir(execPath), false)
+	f, err := inspectExecutable(execPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.Stripped {
+		t.Error("the release executable is not stripped")
+	}
+	want := []setting{
+		{"source", sum},
+		{"revision", commons.GitRevision(dir)},
+		{"core", commons.CoreVersion(sources.Core)},
+	}
+	if !reflect.DeepEqual(f.Provenance, want) {
+		t.Errorf("provenance %v, want %v", f.Provenance, want)
+	}
+}
@@ -0,0 +1,236 @@
+package invoke
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/builder"
+	"github.com/siscia/effe-tool/commons"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// newRequest creates the request from the command line flags.
+// Headers are in the form `Key: Value` and the data can be read
+// from a file using `@path`.
+func newRequest(method, path string, headers []string, data string) (commons.Request, error) {
+	r := commons.Request{Method: method, Path: path, Headers: map[string][]string{}}
+	for _, header := range headers {
+		kv := strings.SplitN(header, ":", 2)
+		if len(kv) != 2 {
+			return r, errors.New("Invalid header, it should be `Key: Value`: " + header)
+		}
+		key := http.CanonicalHeaderKey(strings.TrimSpace(kv[0]))
+		r.Headers[key] = append(r.Headers[key], strings.TrimSpace(kv[1]))
+	}
+	body := []byte(data)
+	if strings.HasPrefix(data, "@") {
+		var err error
+		if body, err = ioutil.ReadFile(strings.TrimPrefix(data, "@")); err != nil {
+			return r, err
+		}
+	}
+	r.Body = base64.StdEncoding.EncodeToString(body)
+	r.IsBase64Encoded = true
+	return r, nil
+}
+
+// printResponse writes status, headers and body of the response
+// on the standard output.
+func printResponse(r commons.Response) {
+	fmt.Printf("%d %s\n", r.Status, http.StatusText(r.Status))
+	keys := make([]string, 0, len(r.Headers))
+	for key := range r.Headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range r.Headers[key] {
+			fmt.Println(key + ": " + value)
+		}
+	}
+	fmt.Println()
+	body, _ := r.DecodedBody()
+	os.Stdout.Write(body)
+}
+
+// freePort asks the kernel for a free ephemeral port.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// waitReady waits until the effe accepts connections on `address`,
+// it gives up if the effe exits or if `timeout` expires.
+func waitReady(address string, exited chan error, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		select {
+		case err := <-exited:
+			return fmt.Errorf("The effe exited before being ready: %v", err)
+		default:
+		}
+		conn, err := net.DialTimeout("tcp", address, 100*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return errors.New("The effe is not ready after " + timeout.String())
+}
+
+// invokeServer starts the effe on a free port, sends the request
+// and shuts the effe down.
+func invokeServer(execPath string, r commons.Request, timeout time.Duration) (commons.Response, error) {
+	var res commons.Response
+	port, err := freePort()
+	if err != nil {
+		return res, err
+	}
+	cmd := exec.Command(execPath, "-listen", "tcp:127.0.0.1:"+strconv.Itoa(port))
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return res, err
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	defer func() {
+		cmd.Process.Kill()
+		<-exited
+	}()
+
+	address := "127.0.0.1:" + strconv.Itoa(port)
+	if err := waitReady(address, exited, timeout); err != nil {
+		return res, err
+	}
+
+	body, err := r.DecodedBody()
+	if err != nil {
+		return res, err
+	}
+	req, err := http.NewRequest(r.Method, "http://"+address+r.Path, bytes.NewReader(body))
+	if err != nil {
+		return res, err
+	}
+	for key, values := range r.Headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if host := req.Header.Get("Host"); host != "" {
+		req.Host = host
+	}
+	client := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return res, err
+	}
+	defer resp.Body.Close()
+	body, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return res, err
+	}
+	res.Status = resp.StatusCode
+	res.Headers = resp.Header
+	res.Body = base64.StdEncoding.EncodeToString(body)
+	res.IsBase64Encoded = true
+	return res, nil
+}
+
+// invokeOneShot runs the effe in one-shot mode passing the request
+// on the standard input, it gives up if `timeout` expires.
+// As for a server, an error of the logic is printed by the effe on
+// the standard error and the response is still returned.
+func invokeOneShot(execPath string, r commons.Request, timeout time.Duration) (commons.Response, error) {
+	var res commons.Response
+	input, err := json.Marshal(r)
+	if err != nil {
+		return res, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, execPath, "-invoke", "-")
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &output
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return res, errors.New("The effe did not answer after " + timeout.String())
+	}
+	if err := json.NewDecoder(&output).Decode(&res); err != nil {
+		if runErr != nil {
+			return res, errors.New("The effe exited without answering: " + runErr.Error())
+		}
+		return res, errors.New("Impossible to read the response of the effe: " + err.Error())
+	}
+	return res, nil
+}
+
+// Invoke is the main entry point, it calls the effe passed as
+// argument, compiling it first if it is a source file.
+func Invoke(c *cli.Context) {
+	path := c.Args().First()
+	f, err := os.Stat(path)
+	if err != nil || !f.Mode().IsRegular() {
+		fmt.Println("File: " + path + " | Impossible to open the file, are you sure it exist ?")
+		return
+	}
+	r, err := newRequest(c.String("method"), c.String("path"), c.StringSlice("header"), c.String("data"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	execPath := path
+	if filepath.Ext(path) == ".go" {
+		opts, err := builder.LoadOptions(path, c)
+		if err != nil {
+			fmt.Println("File: " + path + " | " + err.Error())
+			return
+		}
+		execPath, err = builder.CompileSingleFile(path, opts)
+		if err != nil {
+			fmt.Println("File: " + path + " | Impossible to compile.")
+			return
+		}
+		defer commons.CloseWorkspace(filepath.Dir(execPath), false)
+	} else if !filepath.IsAbs(execPath) {
+		execPath = "./" + execPath
+	}
+
+	var res commons.Response
+	if c.Bool("oneshot") {
+		res, err = invokeOneShot(execPath, r, c.Duration("timeout"))
+	} else {
+		res, err = invokeServer(execPath, r, c.Duration("timeout"))
+	}
+	if err != nil {
+		fmt.Println("File: " + path + " | " + err.Error())
+		return
+	}
+	printResponse(res)
+}
@@ -0,0 +1,549 @@
+package lint
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// Analyzers are the checks run by `effe-tool lint`, in order.
+var Analyzers = []*analysis.Analyzer{
+	InfoJSON,
+	GlobalMutation,
+	StartError,
+	HeaderAfterBody,
+	InitBlocking,
+	StopCleanup,
+}
+
+// InfoJSON checks that Info is a string variable holding a JSON
+// object with a name and a version.
+var InfoJSON = &analysis.Analyzer{
+	Name: "infojson",
+	Doc:  "check that Info is valid JSON with a name and a version",
+	Run:  runInfoJSON,
+}
+
+// GlobalMutation reports assignments to package-level variables
+// from Run, effes must not keep state between requests.
+var GlobalMutation = &analysis.Analyzer{
+	Name: "globalmutation",
+	Doc:  "check that Run does not modify package-level variables",
+	Run:  runGlobalMutation,
+}
+
+// StartError reports a Run that ignores the error returned by Start.
+var StartError = &analysis.Analyzer{
+	Name: "starterror",
+	Doc:  "check that Run uses the error returned by Start",
+	Run:  runStartError,
+}
+
+// HeaderAfterBody reports headers modified after the body, or the
+// status, has been written, net/http ignores them.
+var HeaderAfterBody = &analysis.Analyzer{
+	Name: "headerafterbody",
+	Doc:  "check that headers are set before writing the response",
+	Run:  runHeaderAfterBody,
+}
+
+// InitBlocking reports calls that may block inside Init, which runs
+// before the effe accepts any request.
+var InitBlocking = &analysis.Analyzer{
+	Name: "initblocking",
+	Doc:  "check that Init does not block",
+	Run:  runInitBlocking,
+}
+
+// StopCleanup reports resources opened in Start that are never
+// closed, neither in Start nor in Stop.
+var StopCleanup = &analysis.Analyzer{
+	Name: "stopcleanup",
+	Doc:  "check that resources opened in Start are closed in Stop",
+	Run:  runStopCleanup,
+}
+
+// findFunc returns the top level function `name`, or nil.
+func findFunc(pass *analysis.Pass, name string) *ast.FuncDecl {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if f, ok := decl.(*ast.FuncDecl); ok && f.Recv == nil && f.Name.Name == name && f.Body != nil {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// isPackageLevel tells if `obj` is a variable declared at package
+// level, in this package or in an imported one.
+func isPackageLevel(obj types.Object) bool {
+	v, ok := obj.(*types.Var)
+	return ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope()
+}
+
+func runInfoJSON(pass *analysis.Pass) (interface{}, error) {
+	if len(pass.Files) == 0 {
+		return nil, nil
+	}
+	obj := pass.Pkg.Scope().Lookup("Info")
+	if obj == nil {
+		pass.Reportf(pass.Files[0].Name.Pos(), "Info is not declared")
+		return nil, nil
+	}
+	if _, ok := obj.(*types.Var); !ok {
+		pass.Reportf(obj.Pos(), "Info must be a variable, run `effe-tool migrate`")
+		return nil, nil
+	}
+	if !types.Identical(obj.Type(), types.Typ[types.String]) {
+		pass.Reportf(obj.Pos(), "Info must be a string, not %s", obj.Type())
+		return nil, nil
+	}
+
+	var value ast.Expr
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.ValueSpec); ok {
+				for i, name := range spec.Names {
+					if pass.TypesInfo.Defs[name] == obj && i < len(spec.Values) {
+						value = spec.Values[i]
+					}
+				}
+			}
+			return value == nil
+		})
+	}
+	if value == nil {
+		pass.Reportf(obj.Pos(), "Info has no value")
+		return nil, nil
+	}
+	tv := pass.TypesInfo.Types[value]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		pass.Reportf(value.Pos(), "Info must be a constant string")
+		return nil, nil
+	}
+
+	var info map[string]interface{}
+	if err := json.Unmarshal([]byte(constant.StringVal(tv.Value)), &info); err != nil {
+		pass.Reportf(value.Pos(), "Info is not valid JSON: %s", err)
+		return nil, nil
+	}
+	for _, key := range []string{"name", "version"} {
+		if s, ok := info[key].(string); !ok || s == "" {
+			pass.Reportf(value.Pos(), "Info has no %q, or it is not a string", key)
+		}
+	}
+	return nil, nil
+}
+
+// assignedRoot returns the identifier of the variable modified by
+// assigning to `expr`: the variable itself, the struct whose field
+// is assigned or the map or slice whose element is assigned.
+func assignedRoot(pass *analysis.Pass, expr ast.Expr) *ast.Ident {
+	for {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return e
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			if ident, ok := e.X.(*ast.Ident); ok {
+				if _, ok := pass.TypesInfo.Uses[ident].(*types.PkgName); ok {
+					// a variable of another package
+					return e.Sel
+				}
+			}
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+func runGlobalMutation(pass *analysis.Pass) (interface{}, error) {
+	run := findFunc(pass, "Run")
+	if run == nil {
+		return nil, nil
+	}
+	check := func(expr ast.Expr) {
+		root := assignedRoot(pass, expr)
+		if root == nil {
+			return
+		}
+		if obj := pass.TypesInfo.Uses[root]; obj != nil && isPackageLevel(obj) {
+			name := obj.Name()
+			if obj.Pkg() != pass.Pkg {
+				name = obj.Pkg().Name() + "." + name
+			}
+			pass.Reportf(expr.Pos(), "Run modifies the package-level variable %s, effes must not keep state between requests", name)
+		}
+	}
+	ast.Inspect(run.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				for _, lhs := range n.Lhs {
+					check(lhs)
+				}
+			}
+		case *ast.IncDecStmt:
+			check(n.X)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+func runStartError(pass *analysis.Pass) (interface{}, error) {
+	run := findFunc(pass, "Run")
+	if run == nil {
+		return nil, nil
+	}
+	for _, field := range run.Type.Params.List {
+		if !types.Identical(pass.TypesInfo.TypeOf(field.Type), types.Universe.Lookup("error").Type()) {
+			continue
+		}
+		if len(field.Names) == 0 || field.Names[0].Name == "_" {
+			pass.Reportf(field.Pos(), "Run ignores the error returned by Start")
+			return nil, nil
+		}
+		param := pass.TypesInfo.Defs[field.Names[0]]
+		used := false
+		ast.Inspect(run.Body, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == param {
+				used = true
+			}
+			return !used
+		})
+		if !used {
+			pass.Reportf(field.Names[0].Pos(), "Run never checks %s, the error returned by Start", field.Names[0].Name)
+		}
+		return nil, nil
+	}
+	return nil, nil
+}
+
+// isResponseWriter tells if `t` is net/http.ResponseWriter.
+func isResponseWriter(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "net/http" && obj.Name() == "ResponseWriter"
+}
+
+// headerWriter follows, statement after statement, the functions
+// receiving a http.ResponseWriter and reports the headers modified
+// once the response is written.
+type headerWriter struct {
+	pass *analysis.Pass
+	w    types.Object
+}
+
+// isWriter tells if `expr` is the ResponseWriter.
+func (h headerWriter) isWriter(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && h.pass.TypesInfo.Uses[ident] == h.w
+}
+
+// writes tells if the simple statement `stmt` writes the status or
+// the body: calling Write or WriteHeader, or passing the writer to a
+// function of fmt, io, net/http or to json.NewEncoder.
+func (h headerWriter) writes(stmt ast.Stmt) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok && h.isWriter(sel.X) && (sel.Sel.Name == "Write" || sel.Sel.Name == "WriteHeader") {
+				found = true
+			}
+			if len(n.Args) > 0 && h.isWriter(n.Args[0]) {
+				if fn := typeutil.StaticCallee(h.pass.TypesInfo, n); fn != nil && fn.Pkg() != nil {
+					switch fn.Pkg().Path() {
+					case "fmt", "io", "net/http":
+						found = true
+					}
+				}
+			}
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Encode" {
+				if inner, ok := sel.X.(*ast.CallExpr); ok && len(inner.Args) > 0 && h.isWriter(inner.Args[0]) {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// reportHeaders reports every call to w.Header().Set, Add or Del
+// inside `stmt`.
+func (h headerWriter) reportHeaders(stmt ast.Stmt) {
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || (sel.Sel.Name != "Set" && sel.Sel.Name != "Add" && sel.Sel.Name != "Del") {
+			return true
+		}
+		header, ok := sel.X.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if hsel, ok := header.Fun.(*ast.SelectorExpr); ok && hsel.Sel.Name == "Header" && h.isWriter(hsel.X) {
+			h.pass.Reportf(call.Pos(), "header modified after the response is written, it will be ignored")
+		}
+		return true
+	})
+}
+
+// walk visits the statements in order, `written` is true when the
+// response has already been written.
+// A write inside a branch does not affect the statements after the
+// branch, since the branch may return or may not be taken.
+func (h headerWriter) walk(stmts []ast.Stmt, written bool) {
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.BlockStmt:
+			h.walk(s.List, written)
+		case *ast.IfStmt:
+			h.walk(s.Body.List, written)
+			if s.Else != nil {
+				h.walk([]ast.Stmt{s.Else}, written)
+			}
+		case *ast.ForStmt:
+			h.walk(s.Body.List, written)
+		case *ast.RangeStmt:
+			h.walk(s.Body.List, written)
+		case *ast.SwitchStmt:
+			h.walk(s.Body.List, written)
+		case *ast.TypeSwitchStmt:
+			h.walk(s.Body.List, written)
+		case *ast.SelectStmt:
+			h.walk(s.Body.List, written)
+		case *ast.CaseClause:
+			h.walk(s.Body, written)
+		case *ast.CommClause:
+			h.walk(s.Body, written)
+		case *ast.LabeledStmt:
+			h.walk([]ast.Stmt{s.Stmt}, written)
+		default:
+			if written {
+				h.reportHeaders(s)
+			} else if h.writes(s) {
+				written = true
+			}
+		}
+	}
+}
+
+func runHeaderAfterBody(pass *analysis.Pass) (interface{}, error) {
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			var params *ast.FieldList
+			var body *ast.BlockStmt
+			switch f := n.(type) {
+			case *ast.FuncDecl:
+				params, body = f.Type.Params, f.Body
+			case *ast.FuncLit:
+				params, body = f.Type.Params, f.Body
+			default:
+				return true
+			}
+			if body == nil {
+				return true
+			}
+			for _, field := range params.List {
+				if !isResponseWriter(pass.TypesInfo.TypeOf(field.Type)) {
+					continue
+				}
+				for _, name := range field.Names {
+					if w := pass.TypesInfo.Defs[name]; w != nil {
+						headerWriter{pass, w}.walk(body.List, false)
+					}
+				}
+			}
+			return true
+		})
+	}
+	return nil, nil
+}
+
+// blockingCalls are the functions that wait on the network, on other
+// processes or on other goroutines.
+var blockingCalls = map[string]bool{
+	"time.Sleep":                           true,
+	"net.Dial":                             true,
+	"net.DialTimeout":                      true,
+	"net/http.Get":                         true,
+	"net/http.Head":                        true,
+	"net/http.Post":                        true,
+	"net/http.PostForm":                    true,
+	"net/http.ListenAndServe":              true,
+	"net/http.ListenAndServeTLS":           true,
+	"(*net/http.Client).Do":                true,
+	"(*net/http.Client).Get":               true,
+	"(*net/http.Client).Head":              true,
+	"(*net/http.Client).Post":              true,
+	"(*net/http.Client).PostForm":          true,
+	"(*os/exec.Cmd).Run":                   true,
+	"(*os/exec.Cmd).Wait":                  true,
+	"(*os/exec.Cmd).Output":                true,
+	"(*os/exec.Cmd).CombinedOutput":        true,
+	"(*sync.WaitGroup).Wait":               true,
+	"(*sync.Cond).Wait":                    true,
+	"(*database/sql.DB).Ping":              true,
+	"(*database/sql.DB).PingContext":       true,
+	"(*net/http.Server).ListenAndServe":    true,
+	"(*net/http.Server).ListenAndServeTLS": true,
+}
+
+func runInitBlocking(pass *analysis.Pass) (interface{}, error) {
+	init := findFunc(pass, "Init")
+	if init == nil {
+		return nil, nil
+	}
+	report := func(pos token.Pos, what string) {
+		pass.Reportf(pos, "%s may block Init and delay the start of the effe", what)
+	}
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GoStmt, *ast.FuncLit:
+			// they do not run inside Init
+			return false
+		case *ast.CallExpr:
+			if fn := typeutil.StaticCallee(pass.TypesInfo, n); fn != nil && blockingCalls[fn.FullName()] {
+				report(n.Pos(), fn.FullName())
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW {
+				report(n.Pos(), "receiving from a channel")
+			}
+		case *ast.SendStmt:
+			report(n.Pos(), "sending on a channel")
+		case *ast.SelectStmt:
+			// the communications of the clauses block only without a
+			// default, as the whole select, but the bodies may
+			withDefault := false
+			for _, clause := range n.Body.List {
+				withDefault = withDefault || clause.(*ast.CommClause).Comm == nil
+			}
+			if !withDefault {
+				report(n.Pos(), "select without default")
+			}
+			for _, clause := range n.Body.List {
+				for _, stmt := range clause.(*ast.CommClause).Body {
+					ast.Inspect(stmt, visit)
+				}
+			}
+			return false
+		case *ast.ForStmt:
+			if n.Cond == nil {
+				report(n.Pos(), "loop without condition")
+			}
+		}
+		return true
+	}
+	ast.Inspect(init.Body, visit)
+	return nil, nil
+}
+
+// packageName qualifies the types of other packages with the name
+// of the package, as they are written in the source.
+func packageName(current *types.Package) types.Qualifier {
+	return func(p *types.Package) string {
+		if p == current {
+			return ""
+		}
+		return p.Name()
+	}
+}
+
+// closable returns the types, among the results of `call`, that have
+// a Close method.
+func closable(pass *analysis.Pass, call *ast.CallExpr) []types.Type {
+	t := pass.TypesInfo.TypeOf(call)
+	if t == nil {
+		return nil
+	}
+	results := []types.Type{t}
+	if tuple, ok := t.(*types.Tuple); ok {
+		results = nil
+		for i := 0; i < tuple.Len(); i++ {
+			results = append(results, tuple.At(i).Type())
+		}
+	}
+	found := []types.Type{}
+	for _, r := range results {
+		obj, _, _ := types.LookupFieldOrMethod(r, true, pass.Pkg, "Close")
+		if _, ok := obj.(*types.Func); ok {
+			found = append(found, r)
+		}
+	}
+	return found
+}
+
+// closedTypes returns the types on which Close is called in `fn`.
+func closedTypes(pass *analysis.Pass, fn *ast.FuncDecl) []types.Type {
+	closed := []types.Type{}
+	if fn == nil {
+		return closed
+	}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Close" {
+				if t := pass.TypesInfo.TypeOf(sel.X); t != nil {
+					closed = append(closed, t)
+				}
+			}
+		}
+		return true
+	})
+	return closed
+}
+
+func runStopCleanup(pass *analysis.Pass) (interface{}, error) {
+	start := findFunc(pass, "Start")
+	if start == nil {
+		return nil, nil
+	}
+	closed := append(closedTypes(pass, start), closedTypes(pass, findFunc(pass, "Stop"))...)
+	isClosed := func(t types.Type) bool {
+		for _, c := range closed {
+			if types.Identical(c, t) {
+				return true
+			}
+		}
+		return false
+	}
+	ast.Inspect(start.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		for _, t := range closable(pass, call) {
+			if isClosed(t) {
+				continue
+			}
+			// reporting every type only once
+			closed = append(closed, t)
+			pass.Reportf(call.Pos(), "%s opened in Start is never closed, close it in Stop", types.TypeString(t, packageName(pass.Pkg)))
+		}
+		return true
+	})
+	return nil, nil
+}
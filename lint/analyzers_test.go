@@ -0,0 +1,27 @@
+package lint
+
+import (
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"testing"
+)
+
+func TestAnalyzers(t *testing.T) {
+	cases := []struct {
+		analyzer *analysis.Analyzer
+		packages []string
+	}{
+		{InfoJSON, []string{"infojson/good", "infojson/missing", "infojson/notjson", "infojson/constant"}},
+		{GlobalMutation, []string{"globalmutation"}},
+		{StartError, []string{"starterror", "starterror/ignored", "starterror/unused"}},
+		{HeaderAfterBody, []string{"headerafterbody"}},
+		{InitBlocking, []string{"initblocking"}},
+		{StopCleanup, []string{"stopcleanup"}},
+	}
+	if len(cases) != len(Analyzers) {
+		t.Fatalf("%d analyzers tested, %d in Analyzers", len(cases), len(Analyzers))
+	}
+	for _, c := range cases {
+		analysistest.Run(t, analysistest.TestData(), c.analyzer, c.packages...)
+	}
+}
@@ -0,0 +1,173 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/commons"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+)
+
+// Diagnostic is a problem found in an effe, errors parsing or type
+// checking the file are reported by the "compile" analyzer.
+type Diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Analyzer string `json:"analyzer"`
+	Message  string `json:"message"`
+}
+
+func newDiagnostic(fset *token.FileSet, pos token.Pos, analyzer, message string) Diagnostic {
+	p := fset.Position(pos)
+	return Diagnostic{p.Filename, p.Line, p.Column, analyzer, message}
+}
+
+// linter type checks effes, the importer is shared so that the
+// standard library is loaded only once.
+type linter struct {
+	fset     *token.FileSet
+	importer types.Importer
+}
+
+func newLinter() *linter {
+	fset := token.NewFileSet()
+	return &linter{fset, importer.ForCompiler(fset, "source", nil)}
+}
+
+// lintFile runs every analyzer on the effe at `path`.
+// The analyzers of effe-tool require no other analyzer and use no
+// facts, so each one runs on its own pass.
+func (l *linter) lintFile(path string) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	file, err := parser.ParseFile(l.fset, path, nil, parser.ParseComments)
+	if err != nil {
+		if list, ok := err.(scanner.ErrorList); ok {
+			for _, e := range list {
+				diagnostics = append(diagnostics, Diagnostic{path, e.Pos.Line, e.Pos.Column, "compile", e.Msg})
+			}
+			return diagnostics
+		}
+		return append(diagnostics, Diagnostic{path, 0, 0, "compile", err.Error()})
+	}
+
+	typeErrors := []Diagnostic{}
+	conf := types.Config{
+		Importer: l.importer,
+		Error: func(err error) {
+			if e, ok := err.(types.Error); ok {
+				typeErrors = append(typeErrors, newDiagnostic(e.Fset, e.Pos, "compile", e.Msg))
+			}
+		},
+	}
+	info := &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Implicits:  map[ast.Node]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+		Scopes:     map[ast.Node]*types.Scope{},
+	}
+	pkg, _ := conf.Check(file.Name.Name, l.fset, []*ast.File{file}, info)
+	if len(typeErrors) > 0 {
+		// the analyzers would report nonsense on a broken file
+		return typeErrors
+	}
+
+	for _, a := range Analyzers {
+		analyzer := a
+		pass := &analysis.Pass{
+			Analyzer:   analyzer,
+			Fset:       l.fset,
+			Files:      []*ast.File{file},
+			Pkg:        pkg,
+			TypesInfo:  info,
+			TypesSizes: types.SizesFor("gc", runtime.GOARCH),
+			ResultOf:   map[*analysis.Analyzer]interface{}{},
+			Report: func(d analysis.Diagnostic) {
+				diagnostics = append(diagnostics, newDiagnostic(l.fset, d.Pos, analyzer.Name, d.Message))
+			},
+		}
+		if _, err := analyzer.Run(pass); err != nil {
+			diagnostics = append(diagnostics, Diagnostic{path, 0, 0, analyzer.Name, err.Error()})
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		return diagnostics[i].Column < diagnostics[j].Column
+	})
+	return diagnostics
+}
+
+// reportHuman prints the diagnostics like the go tools do, so that
+// editors can jump to them.
+func reportHuman(w io.Writer, diagnostics []Diagnostic) {
+	for _, d := range diagnostics {
+		fmt.Fprintf(w, "%s:%d:%d: %s (%s)\n", d.File, d.Line, d.Column, d.Message, d.Analyzer)
+	}
+	fmt.Fprintf(w, "\n%d problems\n", len(diagnostics))
+}
+
+func reportJSON(w io.Writer, diagnostics []Diagnostic) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(diagnostics)
+}
+
+// Lint is the main entry point, it checks a single effe or every
+// effe inside the directory passed as argument.
+// The exit status is not zero if any problem is found.
+func Lint(c *cli.Context) {
+	path := c.Args().First()
+	f, err := os.Lstat(path)
+	if err != nil {
+		fmt.Println("Impossible to open the file, are you sure it exist ?")
+		return
+	}
+
+	l := newLinter()
+	diagnostics := []Diagnostic{}
+	if f.IsDir() {
+		ignore := commons.LoadIgnore(path)
+		filepath.Walk(path, func(path string, f os.FileInfo, _ error) error {
+			if ignore.Match(path) {
+				if f.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if f.Mode().IsRegular() && filepath.Ext(path) == ".go" {
+				diagnostics = append(diagnostics, l.lintFile(path)...)
+			}
+			return nil
+		})
+	}
+	if f.Mode().IsRegular() {
+		diagnostics = append(diagnostics, l.lintFile(path)...)
+	}
+
+	if c.String("format") == "json" {
+		if err := reportJSON(os.Stdout, diagnostics); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	} else {
+		reportHuman(os.Stdout, diagnostics)
+	}
+	if len(diagnostics) > 0 {
+		os.Exit(1)
+	}
+}
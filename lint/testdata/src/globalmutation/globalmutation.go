@@ -0,0 +1,36 @@
+package logic
+
+import (
+	"net/http"
+	"os"
+)
+
+type Context struct{}
+
+var (
+	counter int
+	cache   = map[string]string{}
+	config  struct{ name string }
+	limits  [3]int
+)
+
+func Init() {
+	// Init runs once, it may set the globals
+	counter = 1
+}
+
+func Run(ctx Context, err error, w http.ResponseWriter, r *http.Request) error {
+	counter++                           // want "Run modifies the package-level variable counter"
+	cache[r.URL.Path] = "seen"          // want "Run modifies the package-level variable cache"
+	config.name = r.URL.Query()["n"][0] // want "Run modifies the package-level variable config"
+	(limits)[0] = 2                     // want "Run modifies the package-level variable limits"
+	os.Args = nil                       // want "Run modifies the package-level variable os.Args"
+
+	local := counter
+	local++
+	copied := cache
+	_ = copied
+	var other struct{ name string }
+	other.name = "local"
+	return err
+}
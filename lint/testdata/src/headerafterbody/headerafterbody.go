@@ -0,0 +1,51 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+func write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("hello"))
+	w.Header().Set("X-After", "1") // want "header modified after the response is written, it will be ignored"
+}
+
+func status(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusCreated)
+	w.Header().Add("X-After", "1") // want "header modified after the response is written, it will be ignored"
+	w.Header().Del("X-Other")      // want "header modified after the response is written, it will be ignored"
+}
+
+func helpers(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintln(w, "hello")
+	w.Header().Set("X-After", "1") // want "header modified after the response is written, it will be ignored"
+}
+
+func encoder(w http.ResponseWriter) {
+	json.NewEncoder(w).Encode(map[string]string{"a": "b"})
+	w.Header().Set("X-After", "1") // want "header modified after the response is written, it will be ignored"
+}
+
+func copied(w http.ResponseWriter, r io.Reader) {
+	io.Copy(w, r)
+	if true {
+		w.Header().Set("X-After", "1") // want "header modified after the response is written, it will be ignored"
+	}
+}
+
+func branch(w http.ResponseWriter, fail bool) {
+	if fail {
+		http.Error(w, "failed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("ok"))
+}
+
+var handler = func(w http.ResponseWriter, r *http.Request) {
+	w.Write(nil)
+	w.Header().Set("X-After", "1") // want "header modified after the response is written, it will be ignored"
+}
@@ -0,0 +1,5 @@
+package logic
+
+import "strings"
+
+var Info = strings.TrimSpace(`{"name": "constant", "version": "1.0"}`) // want "Info must be a constant string"
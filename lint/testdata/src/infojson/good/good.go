@@ -0,0 +1,3 @@
+package logic
+
+var Info string = `{"name": "good", "version": "1.0"}`
@@ -0,0 +1,3 @@
+package logic // want "Info is not declared"
+
+var info = `{"name": "missing", "version": "1.0"}`
@@ -0,0 +1,5 @@
+package logic
+
+var Info = `{"name": "notjson",` // want "Info is not valid JSON: unexpected end of JSON input"
+
+var Other = `{"name": "other"}`
@@ -0,0 +1,58 @@
+package logic
+
+import (
+	"net/http"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+var (
+	ready = make(chan bool)
+	wg    sync.WaitGroup
+)
+
+func Init() {
+	time.Sleep(time.Second)  // want "time.Sleep may block Init and delay the start of the effe"
+	http.Get("http://effe")  // want "net/http.Get may block Init and delay the start of the effe"
+	exec.Command("ls").Run() // want `\(\*os/exec.Cmd\).Run may block Init and delay the start of the effe`
+	wg.Wait()                // want `\(\*sync.WaitGroup\).Wait may block Init and delay the start of the effe`
+	<-ready                  // want "receiving from a channel may block Init and delay the start of the effe"
+	ready <- true            // want "sending on a channel may block Init and delay the start of the effe"
+	select {                 // want "select without default may block Init and delay the start of the effe"
+	case <-time.After(0):
+	}
+	for { // want "loop without condition may block Init and delay the start of the effe"
+		break
+	}
+
+	// they do not block Init
+	go func() {
+		time.Sleep(time.Second)
+		<-ready
+	}()
+	later := func() { time.Sleep(time.Second) }
+	_ = later
+	select {
+	case <-ready:
+	default:
+	}
+	select {
+	case v := <-ready:
+		_ = v
+	case ready <- true:
+	default:
+	}
+	select {
+	case <-ready:
+		time.Sleep(time.Second) // want "time.Sleep may block Init and delay the start of the effe"
+	default:
+	}
+	for i := 0; i < 3; i++ {
+	}
+}
+
+func Start() {
+	// only Init is checked
+	time.Sleep(time.Second)
+}
@@ -0,0 +1,9 @@
+package logic
+
+import "net/http"
+
+type Context struct{}
+
+func Run(ctx Context, _ error, w http.ResponseWriter, r *http.Request) error { // want "Run ignores the error returned by Start"
+	return nil
+}
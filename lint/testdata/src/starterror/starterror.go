@@ -0,0 +1,12 @@
+package logic
+
+import "net/http"
+
+type Context struct{}
+
+func Run(ctx Context, err error, w http.ResponseWriter, r *http.Request) error {
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package logic
+
+import "net/http"
+
+type Context struct{}
+
+func Run(ctx Context, startErr error, w http.ResponseWriter, r *http.Request) error { // want "Run never checks startErr, the error returned by Start"
+	return nil
+}
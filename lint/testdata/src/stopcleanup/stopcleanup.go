@@ -0,0 +1,30 @@
+package logic
+
+import (
+	"net"
+	"os"
+)
+
+type Context struct {
+	file     *os.File
+	log      *os.File
+	listener net.Listener
+}
+
+func Start() (Context, error) {
+	file, err := os.Open("data") // want `\*os.File opened in Start is never closed, close it in Stop`
+	if err != nil {
+		return Context{}, err
+	}
+	log, _ := os.Create("log")
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return Context{}, err
+	}
+	listener.Close()
+	return Context{file, log, listener}, nil
+}
+
+func Stop(ctx Context) {
+	ctx.listener.Close()
+}
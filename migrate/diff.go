@@ -0,0 +1,126 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffLine struct {
+	kind byte // ' ', '-' or '+'
+	text string
+}
+
+// diffLines computes the line by line difference between `a` and `b`
+// using the longest common subsequence, effes are small enough for
+// the quadratic algorithm.
+func diffLines(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	lines := []diffLine{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
+
+// splitLines splits the text in lines, the newline ending the
+// last line does not start a new one.
+func splitLines(text string) []string {
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// unifiedDiff returns the difference between the two versions of
+// the file at `path` in the unified format.
+func unifiedDiff(path, before, after string) string {
+	lines := diffLines(splitLines(before), splitLines(after))
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", path, path)
+
+	// line numbers, in the old and in the new file, of every line
+	oldLine, newLine := make([]int, len(lines)), make([]int, len(lines))
+	o, n := 1, 1
+	for k, l := range lines {
+		oldLine[k], newLine[k] = o, n
+		if l.kind != '+' {
+			o++
+		}
+		if l.kind != '-' {
+			n++
+		}
+	}
+
+	for k := 0; k < len(lines); {
+		if lines[k].kind == ' ' {
+			k++
+			continue
+		}
+		// a hunk starts with some context and ends when there are
+		// more than 2*diffContext unchanged lines
+		start := k - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end, unchanged := k, 0
+		for end < len(lines) && unchanged <= 2*diffContext {
+			if lines[end].kind == ' ' {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+			end++
+		}
+		end -= unchanged - diffContext
+		if unchanged < diffContext {
+			end = len(lines)
+		}
+		oldCount, newCount := 0, 0
+		for _, l := range lines[start:end] {
+			if l.kind != '+' {
+				oldCount++
+			}
+			if l.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", oldLine[start], oldCount, newLine[start], newCount)
+		for _, l := range lines[start:end] {
+			out.WriteString(string(l.kind) + l.text + "\n")
+		}
+		k = end
+	}
+	return out.String()
+}
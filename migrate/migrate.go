@@ -0,0 +1,118 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/commons"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func logError(path, msg string) {
+	fmt.Println("File: " + path + " | " + msg)
+}
+
+// migrateSource applies every migration to the source and returns
+// the new source together with the names of the migrations applied.
+func migrateSource(path string, source []byte) ([]byte, []string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, source, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+	applied := []string{}
+	var extra bytes.Buffer
+	for _, m := range migrations {
+		if m.apply(path, file, &extra) {
+			applied = append(applied, m.name)
+		}
+	}
+	if len(applied) == 0 {
+		return source, applied, nil
+	}
+	var out bytes.Buffer
+	if err := format.Node(&out, fset, file); err != nil {
+		return nil, nil, err
+	}
+	// new declarations go at the end of the file
+	out.Write(extra.Bytes())
+	migrated, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	return migrated, applied, nil
+}
+
+// migrateFile upgrades the effe at `path`, with `dryRun` the file is
+// not modified and the diff of the changes is printed instead.
+func migrateFile(path string, dryRun bool) error {
+	log := func(msg string) {
+		logError(path, msg)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		log("Impossible to open the file.")
+		return err
+	}
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		log("Impossible to read the file.")
+		return err
+	}
+	migrated, applied, err := migrateSource(path, source)
+	if err != nil {
+		log("Impossible to parse the file.")
+		return err
+	}
+	if len(applied) == 0 {
+		log("Already up to date.")
+		return nil
+	}
+	if dryRun {
+		log("Would apply: " + strings.Join(applied, ", "))
+		fmt.Print(unifiedDiff(path, string(source), string(migrated)))
+		return nil
+	}
+	if err := ioutil.WriteFile(path, migrated, info.Mode().Perm()); err != nil {
+		log("Impossible to write the file.")
+		return err
+	}
+	log("Migrated: " + strings.Join(applied, ", "))
+	return nil
+}
+
+// Migrate is the main entry point, it upgrades a single effe or every
+// effe inside the directory passed as argument.
+func Migrate(c *cli.Context) {
+	path := c.Args().First()
+	f, err := os.Lstat(path)
+	if err != nil {
+		fmt.Println("Impossible to open the file, are you sure it exist ?")
+		return
+	}
+	if f.IsDir() {
+		ignore := commons.LoadIgnore(path)
+		filepath.Walk(path, func(path string, f os.FileInfo, _ error) error {
+			if ignore.Match(path) {
+				if f.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if f.Mode().IsRegular() && filepath.Ext(path) == ".go" {
+				migrateFile(path, c.Bool("dry-run"))
+			}
+			return nil
+		})
+	}
+	if f.Mode().IsRegular() {
+		migrateFile(path, c.Bool("dry-run"))
+	}
+}
@@ -0,0 +1,71 @@
+package migrate
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of testdata")
+
+// TestMigrateSource migrates every testdata/*.input file and compares
+// the result with the .golden file next to it; a file already
+// migrated, or one the tool can't migrate, has a golden file equal to
+// its input.
+// `go test -update` rewrites the golden files.
+func TestMigrateSource(t *testing.T) {
+	cases := []struct {
+		name    string
+		applied []string
+	}{
+		{name: "const_info", applied: []string{"info-variable"}},
+		{name: "json_comments", applied: []string{"info-json"}},
+		{name: "start_context", applied: []string{"start-error-result"}},
+		{name: "run_three_args", applied: []string{"run-error-parameter", "init-stub", "stop-stub"}},
+		{name: "migrated", applied: []string{}},
+		{name: "grouped_const", applied: []string{}},
+	}
+	for _, c := range cases {
+		input := filepath.Join("testdata", c.name+".input")
+		golden := filepath.Join("testdata", c.name+".golden")
+		source, err := ioutil.ReadFile(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		migrated, applied, err := migrateSource(input, source)
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(applied, c.applied) {
+			t.Errorf("%s: applied %v, want %v", c.name, applied, c.applied)
+		}
+		if *update {
+			if err := ioutil.WriteFile(golden, migrated, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(migrated) != string(want) {
+			t.Errorf("%s:\n%s", c.name, unifiedDiff(golden, string(want), string(migrated)))
+		}
+
+		// migrating again changes nothing
+		again, applied, err := migrateSource(golden, migrated)
+		if err != nil || len(applied) != 0 || string(again) != string(migrated) {
+			t.Errorf("%s: migrated twice, applied %v, error %v", c.name, applied, err)
+		}
+	}
+}
+
+func TestMigrateSourceInvalid(t *testing.T) {
+	source := []byte("package logic\n\nfunc Run(ctx Context {\n}\n")
+	if _, _, err := migrateSource("broken.go", source); err == nil {
+		t.Error("an effe that doesn't parse was migrated")
+	}
+}
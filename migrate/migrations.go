@@ -0,0 +1,258 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// migration upgrades an effe toward the current contract,
+// `apply` modifies the file in place, or writes the source of new
+// declarations in `extra`, and returns true if it changed anything.
+// To support a new contract change it is enough to append a
+// migration to `migrations`, they are applied in order.
+type migration struct {
+	name  string
+	apply func(path string, file *ast.File, extra *bytes.Buffer) bool
+}
+
+var migrations = []migration{
+	{"info-variable", infoVariable},
+	{"info-json", infoJSON},
+	{"start-error-result", startErrorResult},
+	{"run-error-parameter", runErrorParameter},
+	{"init-stub", initStub},
+	{"stop-stub", stopStub},
+}
+
+func findFunc(file *ast.File, name string) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if f, ok := decl.(*ast.FuncDecl); ok && f.Recv == nil && f.Name.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// findInfo returns the spec declaring Info and its declaration.
+func findInfo(file *ast.File) (*ast.GenDecl, *ast.ValueSpec) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || (gen.Tok != token.VAR && gen.Tok != token.CONST) {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for _, name := range value.Names {
+				if name.Name == "Info" {
+					return gen, value
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// contextType returns the name of the type used as context, looking
+// at the first result of Start and at the first parameter of Run.
+func contextType(file *ast.File) string {
+	if start := findFunc(file, "Start"); start != nil && start.Type.Results != nil && len(start.Type.Results.List) > 0 {
+		if ident, ok := start.Type.Results.List[0].Type.(*ast.Ident); ok {
+			return ident.Name
+		}
+	}
+	if run := findFunc(file, "Run"); run != nil && len(run.Type.Params.List) > 0 {
+		if ident, ok := run.Type.Params.List[0].Type.(*ast.Ident); ok {
+			return ident.Name
+		}
+	}
+	return "Context"
+}
+
+// usesName tells if the identifier `name` appears inside `node`.
+func usesName(node ast.Node, name string) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == name {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// infoVariable makes sure that Info is a `var Info string`: old effes
+// declared it as a constant or without type, the oldest had none.
+func infoVariable(path string, file *ast.File, extra *bytes.Buffer) bool {
+	gen, spec := findInfo(file)
+	if gen == nil {
+		base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		name := strings.Trim(invalidNameChars.ReplaceAllString(base, "_"), "_")
+		extra.WriteString("\nvar Info string = `\n{\n\t\"name\": " + strconv.Quote(name) + ",\n\t\"version\": \"0.1\",\n\t\"doc\" : \"\"\n}\n`\n")
+		return true
+	}
+	changed := false
+	if gen.Tok == token.CONST {
+		if len(gen.Specs) > 1 || len(spec.Names) > 1 || len(spec.Values) != 1 {
+			// Info is declared together with other constants,
+			// the migration would need to split the declaration
+			return false
+		}
+		gen.Tok = token.VAR
+		changed = true
+	}
+	if spec.Type == nil {
+		spec.Type = ast.NewIdent("string")
+		changed = true
+	}
+	return changed
+}
+
+var trailingCommas = regexp.MustCompile(`,(\s*[}\]])`)
+
+// stripJSONComments removes `//` comments and trailing commas, which
+// old effes used inside Info but are not valid JSON.
+func stripJSONComments(source string) string {
+	var out bytes.Buffer
+	inString, escaped := false, false
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+		if inString {
+			out.WriteByte(c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		if c == '"' {
+			inString = true
+		}
+		if c == '/' && i+1 < len(source) && source[i+1] == '/' {
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+			// dropping the spaces before the comment as well
+			trimmed := bytes.TrimRight(out.Bytes(), " \t")
+			out.Truncate(len(trimmed))
+			if i < len(source) {
+				out.WriteByte('\n')
+			}
+			continue
+		}
+		out.WriteByte(c)
+	}
+	return trailingCommas.ReplaceAllString(out.String(), "$1")
+}
+
+// infoJSON rewrites Info when it is not valid JSON but it becomes
+// valid once comments and trailing commas are removed.
+func infoJSON(path string, file *ast.File, extra *bytes.Buffer) bool {
+	_, spec := findInfo(file)
+	if spec == nil || len(spec.Values) != 1 {
+		return false
+	}
+	lit, ok := spec.Values[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return false
+	}
+	var document map[string]interface{}
+	if json.Unmarshal([]byte(value), &document) == nil {
+		return false
+	}
+	cleaned := stripJSONComments(value)
+	if json.Unmarshal([]byte(cleaned), &document) != nil {
+		return false
+	}
+	if strings.Contains(cleaned, "`") {
+		lit.Value = strconv.Quote(cleaned)
+	} else {
+		lit.Value = "`" + cleaned + "`"
+	}
+	return true
+}
+
+// startErrorResult upgrades `func Start() Context` to
+// `func Start() (Context, error)`, every return gets a nil error.
+func startErrorResult(path string, file *ast.File, extra *bytes.Buffer) bool {
+	start := findFunc(file, "Start")
+	if start == nil || start.Type.Results == nil || start.Type.Results.NumFields() != 1 {
+		return false
+	}
+	start.Type.Results.List = append(start.Type.Results.List, &ast.Field{Type: ast.NewIdent("error")})
+	if start.Body == nil {
+		return true
+	}
+	ast.Inspect(start.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// returns of closures are not returns of Start
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) == 1 {
+				n.Results = append(n.Results, ast.NewIdent("nil"))
+			}
+		}
+		return true
+	})
+	return true
+}
+
+// runErrorParameter upgrades `Run(ctx, w, r)` to `Run(ctx, err, w, r)`
+// where err is the error returned by Start.
+// If the body already uses `err` the new parameter is `startErr`.
+func runErrorParameter(path string, file *ast.File, extra *bytes.Buffer) bool {
+	run := findFunc(file, "Run")
+	if run == nil || run.Type.Params.NumFields() != 3 {
+		return false
+	}
+	params := run.Type.Params.List
+	for i, field := range params {
+		selector, ok := field.Type.(*ast.SelectorExpr)
+		if !ok || selector.Sel.Name != "ResponseWriter" {
+			continue
+		}
+		if i == 0 || len(field.Names) != 1 {
+			return false
+		}
+		name := "err"
+		if run.Body != nil && usesName(run.Body, "err") {
+			name = "startErr"
+		}
+		errField := &ast.Field{Names: []*ast.Ident{ast.NewIdent(name)}, Type: ast.NewIdent("error")}
+		run.Type.Params.List = append(params[:i:i], append([]*ast.Field{errField}, params[i:]...)...)
+		return true
+	}
+	return false
+}
+
+func initStub(path string, file *ast.File, extra *bytes.Buffer) bool {
+	if findFunc(file, "Init") != nil {
+		return false
+	}
+	extra.WriteString("\nfunc Init() {}\n")
+	return true
+}
+
+func stopStub(path string, file *ast.File, extra *bytes.Buffer) bool {
+	if findFunc(file, "Stop") != nil {
+		return false
+	}
+	extra.WriteString("\nfunc Stop(ctx " + contextType(file) + ") { return }\n")
+	return true
+}
@@ -0,0 +1,120 @@
+// Package naming turns the names and the versions in the Info of the
+// effes into valid file names and image references.
+// Invalid characters are rewritten and every rewrite is reported to
+// `warn`, references chosen by the user are only validated.
+package naming
+
+import (
+	"errors"
+	"github.com/siscia/effe-tool/commons"
+	"regexp"
+	"strings"
+)
+
+var (
+	invalidFileChar       = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+	invalidRepositoryChar = regexp.MustCompile(`[^a-z0-9._-]`)
+	repeatedSeparator     = regexp.MustCompile(`[._-]{2,}`)
+	invalidTagChar        = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+	// reference is the grammar of the image references of docker
+	component = `[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*`
+	reference = regexp.MustCompile(`^(?:[a-zA-Z0-9.-]+(?::[0-9]+)?/)?` +
+		component + `(?:/` + component + `)*` +
+		`(?::[A-Za-z0-9_][A-Za-z0-9_.-]{0,127})?$`)
+)
+
+// maxTag is the longest tag accepted by registries.
+const maxTag = 128
+
+func rewritten(warn func(string), what, from, to string) {
+	if from != to {
+		warn("The " + what + " \"" + from + "\" is not valid, using \"" + to + "\".")
+	}
+}
+
+// Effe returns the name and the version in `info`, the Info of the
+// executable at `path`. Without a name the effe is called effe-
+// followed by the beginning of the sha256 of the executable, and it
+// has no version.
+func Effe(info commons.Info, path string, warn func(string)) (name, version string, err error) {
+	if info.Name != "" {
+		return info.Name, info.Version, nil
+	}
+	sum, err := commons.FileSHA256(path)
+	if err != nil {
+		return "", "", err
+	}
+	name = "effe-" + sum[:12]
+	warn("No name in the Info of the effe, using " + name + ".")
+	return name, "", nil
+}
+
+// FileName returns the name of the executable of an effe, as
+// name_vversion, or only name without a version.
+func FileName(name, version string, warn func(string)) (string, error) {
+	file := name
+	if version != "" {
+		file = name + "_v" + version
+	}
+	clean := strings.TrimLeft(invalidFileChar.ReplaceAllString(file, "_"), ".")
+	if strings.Trim(clean, "_") == "" {
+		return "", errors.New("No valid character in the name \"" + name + "\"")
+	}
+	rewritten(warn, "file name", file, clean)
+	return clean, nil
+}
+
+// Repository returns the image repository for `name`: lowercase
+// letters and digits, separated by single dots, underscores or
+// dashes.
+func Repository(name string, warn func(string)) (string, error) {
+	clean := invalidRepositoryChar.ReplaceAllString(strings.ToLower(name), "-")
+	clean = repeatedSeparator.ReplaceAllString(clean, "-")
+	clean = strings.Trim(clean, "._-")
+	if clean == "" {
+		return "", errors.New("No valid character in the name \"" + name + "\"")
+	}
+	rewritten(warn, "image name", name, clean)
+	return clean, nil
+}
+
+// Tag returns the image tag for `tag`: letters, digits, underscores,
+// dots and dashes, not starting with a dot or a dash.
+func Tag(tag string, warn func(string)) (string, error) {
+	clean := strings.TrimLeft(invalidTagChar.ReplaceAllString(tag, "_"), ".-")
+	if len(clean) > maxTag {
+		clean = clean[:maxTag]
+	}
+	if clean == "" {
+		return "", errors.New("No valid character in the tag \"" + tag + "\"")
+	}
+	rewritten(warn, "image tag", tag, clean)
+	return clean, nil
+}
+
+// Reference returns the image reference `name:version`, latest
+// without a version.
+func Reference(name, version string, warn func(string)) (string, error) {
+	repository, err := Repository(name, warn)
+	if err != nil {
+		return "", err
+	}
+	if version == "" {
+		version = "latest"
+	}
+	tag, err := Tag(version, warn)
+	if err != nil {
+		return "", err
+	}
+	return repository + ":" + tag, nil
+}
+
+// ValidateReference checks a complete image reference, like the ones
+// passed with --tag, which is never rewritten.
+func ValidateReference(ref string) error {
+	if !reference.MatchString(ref) {
+		return errors.New("The image reference \"" + ref + "\" is not valid, it must be [registry/]repository[:tag] with a lowercase repository")
+	}
+	return nil
+}
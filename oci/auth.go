@@ -0,0 +1,123 @@
+package oci
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// dockerHubAuth is the key of Docker Hub in the docker config.
+const dockerHubAuth = "https://index.docker.io/v1/"
+
+// dockerConfig is the part of the docker config file that holds the
+// credentials of the registries.
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// dockerConfigPath is the config of the docker CLI, in
+// $DOCKER_CONFIG or in ~/.docker.
+func dockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+// authKey returns the host of a key of the docker config, keys may
+// be URLs like `https://index.docker.io/v1/`.
+func authKey(key string) string {
+	key = strings.TrimPrefix(key, "https://")
+	key = strings.TrimPrefix(key, "http://")
+	if i := strings.Index(key, "/"); i >= 0 {
+		key = key[:i]
+	}
+	return key
+}
+
+// credentialHelper asks the docker credential helper `helper` for the
+// credentials of `server`.
+func credentialHelper(helper, server string) (string, string, error) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", "", errors.New("The credential helper " + helper + " failed for " + server + ": " + err.Error())
+	}
+	var creds struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return "", "", err
+	}
+	return creds.Username, creds.Secret, nil
+}
+
+// Credentials returns the username and the password of `registry`
+// as the docker CLI stores them after `docker login`: in the config
+// file or in a credential helper. Empty credentials mean anonymous
+// access.
+func Credentials(registry string) (string, string, error) {
+	server := registry
+	if registry == DockerHub {
+		server = dockerHubAuth
+	}
+	content, err := ioutil.ReadFile(dockerConfigPath())
+	if os.IsNotExist(err) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	var config dockerConfig
+	if err := json.Unmarshal(content, &config); err != nil {
+		return "", "", errors.New("Impossible to read the docker config: " + err.Error())
+	}
+
+	if helper, ok := config.CredHelpers[registry]; ok {
+		return credentialHelper(helper, server)
+	}
+	for key, auth := range config.Auths {
+		if authKey(key) != authKey(server) {
+			continue
+		}
+		if auth.Auth == "" {
+			return auth.Username, auth.Password, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", err
+		}
+		parts := bytes.SplitN(decoded, []byte(":"), 2)
+		if len(parts) != 2 {
+			return "", "", errors.New("Wrong credentials in the docker config for " + registry)
+		}
+		return string(parts[0]), string(parts[1]), nil
+	}
+	if config.CredsStore != "" {
+		user, secret, err := credentialHelper(config.CredsStore, server)
+		if err != nil {
+			// the store has no credentials for the registry
+			return "", "", nil
+		}
+		return user, secret, nil
+	}
+	return "", "", nil
+}
@@ -0,0 +1,602 @@
+// Package oci assembles container images without a docker daemon.
+//
+// The images have a single layer with the effe and the CA
+// certificates of the host, they are written as an OCI image layout,
+// a directory, or as a tarball that `docker load` accepts.
+// The images of the same effe for several platforms are gathered in
+// an image index, under the same tags.
+// Timestamps are fixed so the same executable always produces the
+// same image.
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"debug/buildinfo"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Media types of the blobs.
+const (
+	MediaTypeManifest = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeIndex    = "application/vnd.oci.image.index.v1+json"
+	MediaTypeConfig   = "application/vnd.oci.image.config.v1+json"
+	MediaTypeLayer    = "application/vnd.oci.image.layer.v1.tar+gzip"
+)
+
+// Formats of the output.
+const (
+	// FormatLayout is an OCI image layout directory.
+	FormatLayout = "layout"
+	// FormatArchive is a tarball of the OCI image layout plus the
+	// manifest.json of `docker load`.
+	FormatArchive = "archive"
+)
+
+// Formats are all the formats of the output.
+var Formats = []string{FormatArchive, FormatLayout}
+
+// ExecPath is where the effe is placed inside the image.
+const ExecPath = "/exec"
+
+// certsPath is where the CA certificates are placed inside the image,
+// the first place the go runtime looks for them.
+const certsPath = "/etc/ssl/certs/ca-certificates.crt"
+
+// hostCerts are the CA bundles of the most common distributions.
+var hostCerts = []string{
+	"/etc/ssl/certs/ca-certificates.crt",
+	"/etc/pki/tls/certs/ca-bundle.crt",
+	"/etc/ssl/ca-bundle.pem",
+	"/etc/ssl/cert.pem",
+}
+
+// epoch is the timestamp of the files and of the image.
+var epoch = time.Unix(0, 0).UTC()
+
+// Image describes the image of an effe.
+type Image struct {
+	// Exec is the path of the executable
+	Exec string
+	// Tags are the references of the image, as `name:tag`
+	Tags   []string
+	Port   int
+	User   string
+	Env    map[string]string
+	Labels map[string]string
+}
+
+// Descriptor points to a blob.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Platform    *Platform         `json:"platform,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Platform is the platform the image runs on.
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// ParsePlatform parses a platform written as os/arch[/variant], like
+// linux/arm64 or linux/arm/v7; images run only on linux and arm
+// default to v7.
+func ParsePlatform(platform string) (Platform, error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return Platform{}, errors.New("Wrong platform " + platform + ", it must be os/arch[/variant], like linux/arm64")
+	}
+	if parts[0] != "linux" {
+		return Platform{}, errors.New("Wrong platform " + platform + ", the images run only on linux")
+	}
+	p := Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		p.Variant = parts[2]
+	}
+	if p.Architecture == "arm" && p.Variant == "" {
+		p.Variant = "v7"
+	}
+	return p, nil
+}
+
+func (p Platform) String() string {
+	if p.Variant == "" {
+		return p.OS + "/" + p.Architecture
+	}
+	return p.OS + "/" + p.Architecture + "/" + p.Variant
+}
+
+// Healthcheck is the healthcheck of the container, which the OCI spec
+// leaves out and docker adds; durations are in nanoseconds.
+type Healthcheck struct {
+	Test        []string      `json:"Test"`
+	Interval    time.Duration `json:"Interval"`
+	Timeout     time.Duration `json:"Timeout"`
+	StartPeriod time.Duration `json:"StartPeriod"`
+	Retries     int           `json:"Retries"`
+}
+
+// Config is the configuration of the container.
+type Config struct {
+	User         string              `json:"User,omitempty"`
+	Entrypoint   []string            `json:"Entrypoint"`
+	Env          []string            `json:"Env,omitempty"`
+	ExposedPorts map[string]struct{} `json:"ExposedPorts,omitempty"`
+	Labels       map[string]string   `json:"Labels,omitempty"`
+	Healthcheck  *Healthcheck        `json:"Healthcheck,omitempty"`
+}
+
+// RootFS lists the uncompressed digests of the layers.
+type RootFS struct {
+	Type    string   `json:"type"`
+	DiffIDs []string `json:"diff_ids"`
+}
+
+// History is the history of a layer.
+type History struct {
+	Created   time.Time `json:"created"`
+	CreatedBy string    `json:"created_by"`
+}
+
+// ImageConfig is the config blob of the image.
+type ImageConfig struct {
+	Created time.Time `json:"created"`
+	Platform
+	Config  Config    `json:"config"`
+	RootFS  RootFS    `json:"rootfs"`
+	History []History `json:"history"`
+}
+
+// Manifest is the manifest blob of the image.
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+}
+
+// Index is the index.json of the image layout.
+type Index struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Manifests     []Descriptor `json:"manifests"`
+}
+
+// dockerManifest is an entry of the manifest.json of `docker load`.
+type dockerManifest struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+// blob is the content addressed by a Descriptor.
+type blob struct {
+	Descriptor
+	content []byte
+}
+
+func newBlob(mediaType string, content []byte) blob {
+	sum := sha256.Sum256(content)
+	return blob{
+		Descriptor: Descriptor{
+			MediaType: mediaType,
+			Digest:    "sha256:" + hex.EncodeToString(sum[:]),
+			Size:      int64(len(content)),
+		},
+		content: content,
+	}
+}
+
+// path is the path of the blob inside the image layout.
+func (b blob) path() string {
+	return "blobs/sha256/" + strings.TrimPrefix(b.Digest, "sha256:")
+}
+
+func newJSONBlob(mediaType string, v interface{}) (blob, error) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return blob{}, err
+	}
+	return newBlob(mediaType, content), nil
+}
+
+// built is an image ready to be written.
+type built struct {
+	tags     []string
+	config   blob
+	layer    blob
+	manifest blob
+}
+
+// blobs are the blobs of the image, the manifest last.
+func (b built) blobs() []blob {
+	return []blob{b.config, b.layer, b.manifest}
+}
+
+// load is the entry of the image in the manifest.json of
+// `docker load`.
+func (b built) load() dockerManifest {
+	return dockerManifest{
+		Config:   b.config.path(),
+		RepoTags: b.tags,
+		Layers:   []string{b.layer.path()},
+	}
+}
+
+// FindCerts returns the path of the CA bundle of the host, or an
+// empty string if there is none.
+func FindCerts() string {
+	for _, path := range hostCerts {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// ExecPlatform returns the platform the executable at `path` was
+// compiled for, default to the one of effe-tool.
+func ExecPlatform(path string) Platform {
+	platform := Platform{Architecture: runtime.GOARCH, OS: runtime.GOOS}
+	info, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return platform
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "GOOS":
+			platform.OS = setting.Value
+		case "GOARCH":
+			platform.Architecture = setting.Value
+		case "GOARM":
+			platform.Variant = "v" + setting.Value
+		}
+	}
+	return platform
+}
+
+// ImageConfigOf returns the config blob of `image`, without the
+// layers.
+func ImageConfigOf(image Image) ImageConfig {
+	port := strconv.Itoa(image.Port)
+	config := ImageConfig{
+		Created:  epoch,
+		Platform: ExecPlatform(image.Exec),
+		Config: Config{
+			User:         image.User,
+			Entrypoint:   []string{ExecPath, "-port", port},
+			ExposedPorts: map[string]struct{}{port + "/tcp": {}},
+			Labels:       image.Labels,
+			// the same healthcheck of the Dockerfiles, the effe
+			// probes itself since the image has no curl
+			Healthcheck: &Healthcheck{
+				Test:        []string{"CMD", ExecPath, "-probe", "-port", port},
+				Interval:    30 * time.Second,
+				Timeout:     5 * time.Second,
+				StartPeriod: 5 * time.Second,
+				Retries:     3,
+			},
+		},
+		RootFS: RootFS{Type: "layers", DiffIDs: []string{}},
+		History: []History{
+			{Created: epoch, CreatedBy: "effe-tool"},
+		},
+	}
+	for key, value := range image.Env {
+		config.Config.Env = append(config.Config.Env, key+"="+value)
+	}
+	sort.Strings(config.Config.Env)
+	return config
+}
+
+// addFile adds a regular file to the layer.
+func addFile(tw *tar.Writer, name string, mode int64, content []byte) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     mode,
+		Size:     int64(len(content)),
+		ModTime:  epoch,
+		Format:   tar.FormatPAX,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
+
+// addDir adds a directory to the layer.
+func addDir(tw *tar.Writer, name string) error {
+	return tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     name + "/",
+		Mode:     0755,
+		ModTime:  epoch,
+		Format:   tar.FormatPAX,
+	})
+}
+
+// layer returns the layer with the executable and the certificates,
+// uncompressed.
+func layer(exec, certs string) ([]byte, error) {
+	var out bytes.Buffer
+	tw := tar.NewWriter(&out)
+	if certs != "" {
+		content, err := ioutil.ReadFile(certs)
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range []string{"etc", "etc/ssl", "etc/ssl/certs"} {
+			if err := addDir(tw, dir); err != nil {
+				return nil, err
+			}
+		}
+		if err := addFile(tw, strings.TrimPrefix(certsPath, "/"), 0644, content); err != nil {
+			return nil, err
+		}
+	}
+	content, err := ioutil.ReadFile(exec)
+	if err != nil {
+		return nil, err
+	}
+	if err := addFile(tw, strings.TrimPrefix(ExecPath, "/"), 0755, content); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func gzipped(content []byte) ([]byte, error) {
+	var out bytes.Buffer
+	// the header is left empty so that the output depends only on
+	// the content
+	zw := gzip.NewWriter(&out)
+	if _, err := zw.Write(content); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func build(image Image, certs string) (built, error) {
+	tarball, err := layer(image.Exec, certs)
+	if err != nil {
+		return built{}, err
+	}
+	compressed, err := gzipped(tarball)
+	if err != nil {
+		return built{}, err
+	}
+	diffID := newBlob("", tarball).Digest
+	layerBlob := newBlob(MediaTypeLayer, compressed)
+
+	config := ImageConfigOf(image)
+	config.RootFS.DiffIDs = []string{diffID}
+	configBlob, err := newJSONBlob(MediaTypeConfig, config)
+	if err != nil {
+		return built{}, err
+	}
+
+	manifestBlob, err := newJSONBlob(MediaTypeManifest, Manifest{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeManifest,
+		Config:        configBlob.Descriptor,
+		Layers:        []Descriptor{layerBlob.Descriptor},
+	})
+	if err != nil {
+		return built{}, err
+	}
+	platform := config.Platform
+	manifestBlob.Platform = &platform
+	return built{tags: image.Tags, config: configBlob, layer: layerBlob, manifest: manifestBlob}, nil
+}
+
+// files returns the content of the image layout, by path: `root`,
+// the manifest of the image or the index of the images, is tagged
+// with `tags` in index.json and `blobs` are all the other blobs.
+// The archive has also the manifest.json of `docker load` with
+// `load`.
+func files(tags []string, root blob, blobs []blob, archive bool, load []dockerManifest) (map[string][]byte, error) {
+	manifests := []Descriptor{}
+	for _, name := range tags {
+		manifest := root.Descriptor
+		manifest.Annotations = map[string]string{
+			"io.containerd.image.name":          name,
+			"org.opencontainers.image.ref.name": ParseReference(name).Tag,
+		}
+		manifests = append(manifests, manifest)
+	}
+	index, err := json.Marshal(Index{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeIndex,
+		Manifests:     manifests,
+	})
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{
+		"oci-layout": []byte(`{"imageLayoutVersion":"1.0.0"}`),
+		"index.json": index,
+		root.path():  root.content,
+	}
+	for _, b := range blobs {
+		files[b.path()] = b.content
+	}
+	if archive && len(load) > 0 {
+		content, err := json.Marshal(load)
+		if err != nil {
+			return nil, err
+		}
+		files["manifest.json"] = content
+	}
+	return files, nil
+}
+
+func sortedPaths(files map[string][]byte) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func writeLayout(dir string, files map[string][]byte) error {
+	for _, path := range sortedPaths(files) {
+		dest := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(dest, files[path], 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeArchive(path string, files map[string][]byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	var out bytes.Buffer
+	tw := tar.NewWriter(&out)
+	for _, name := range []string{"blobs", "blobs/sha256"} {
+		if err := addDir(tw, name); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedPaths(files) {
+		if err := addFile(tw, name, 0644, files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, out.Bytes(), 0644)
+}
+
+// write writes the image layout at `output` in `format`.
+func write(format, output string, tags []string, root blob, blobs []blob, load []dockerManifest) error {
+	switch format {
+	case FormatLayout:
+		files, err := files(tags, root, blobs, false, nil)
+		if err != nil {
+			return err
+		}
+		return writeLayout(output, files)
+	case FormatArchive:
+		files, err := files(tags, root, blobs, true, load)
+		if err != nil {
+			return err
+		}
+		return writeArchive(output, files)
+	}
+	return errors.New("Unknown format " + format + ", the formats are: " + strings.Join(Formats, ", "))
+}
+
+// Write builds `image` and writes it at `output` in `format`, the
+// CA certificates are taken from `certs`, if not empty.
+// It returns the digest of the manifest.
+func Write(image Image, certs, format, output string) (string, error) {
+	b, err := build(image, certs)
+	if err != nil {
+		return "", err
+	}
+	return b.manifest.Digest, write(format, output, b.tags, b.manifest, b.blobs(), []dockerManifest{b.load()})
+}
+
+// index is a multi-platform image ready to be written.
+type index struct {
+	tags   []string
+	images []built
+	index  blob
+}
+
+// blobs are the blobs of every image.
+func (i index) blobs() []blob {
+	blobs := []blob{}
+	for _, b := range i.images {
+		blobs = append(blobs, b.blobs()...)
+	}
+	return blobs
+}
+
+// load is the image of the platform of effe-tool, the only one
+// `docker load` can use, if there is one.
+func (i index) load() []dockerManifest {
+	for _, b := range i.images {
+		if b.manifest.Platform.OS == "linux" && b.manifest.Platform.Architecture == runtime.GOARCH {
+			return []dockerManifest{b.load()}
+		}
+	}
+	return nil
+}
+
+// buildIndex builds the images, one for each platform, and their
+// index, tagged with the tags of the first image.
+func buildIndex(images []Image, certs string) (index, error) {
+	if len(images) == 0 {
+		return index{}, errors.New("No image for the index")
+	}
+	i := index{tags: images[0].Tags}
+	manifests := []Descriptor{}
+	platforms := map[string]bool{}
+	for _, image := range images {
+		b, err := build(image, certs)
+		if err != nil {
+			return index{}, err
+		}
+		platform := b.manifest.Platform.String()
+		if platforms[platform] {
+			return index{}, errors.New("Two images for the platform " + platform)
+		}
+		platforms[platform] = true
+		i.images = append(i.images, b)
+		manifests = append(manifests, b.manifest.Descriptor)
+	}
+	indexBlob, err := newJSONBlob(MediaTypeIndex, Index{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeIndex,
+		Manifests:     manifests,
+	})
+	if err != nil {
+		return index{}, err
+	}
+	i.index = indexBlob
+	return i, nil
+}
+
+// WriteIndex builds the images, one for each platform, and writes
+// them at `output` in `format` under the tags of the first one, as
+// Write does. The archive can be loaded by `docker load` only when
+// there is an image for the platform of effe-tool.
+// It returns the digest of the index.
+func WriteIndex(images []Image, certs, format, output string) (string, error) {
+	i, err := buildIndex(images, certs)
+	if err != nil {
+		return "", err
+	}
+	return i.index.Digest, write(format, output, i.tags, i.index, i.blobs(), i.load())
+}
@@ -0,0 +1,73 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestImageReadOnly checks that the image runs with a read-only root
+// filesystem: the config declares no volume and no working directory,
+// and the layer has only the executable and the certificates, none of
+// them writable.
+func TestImageReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	exec := filepath.Join(dir, "exec")
+	certs := filepath.Join(dir, "certs.pem")
+	for _, path := range []string{exec, certs} {
+		if err := ioutil.WriteFile(path, []byte(path), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	image := Image{Exec: exec, Tags: []string{"users:1.2"}, Port: 8080, User: "65532:65532"}
+	b, err := build(image, certs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var config struct {
+		Config map[string]json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(b.config.content, &config); err != nil {
+		t.Fatal(err)
+	}
+	keys := []string{}
+	for key := range config.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if want := []string{"Entrypoint", "ExposedPorts", "Healthcheck", "User"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("config %v, want %v", keys, want)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(b.layer.content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(zr)
+	entries := []string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, header.Name)
+		if header.Mode&0022 != 0 {
+			t.Errorf("%s is writable by others than its owner: %o", header.Name, header.Mode)
+		}
+	}
+	want := []string{"etc/", "etc/ssl/", "etc/ssl/certs/", "etc/ssl/certs/ca-certificates.crt", "exec"}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("layer %v, want %v", entries, want)
+	}
+}
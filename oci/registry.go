@@ -0,0 +1,295 @@
+package oci
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DockerHub is the registry of the references without one.
+const DockerHub = "docker.io"
+
+// Reference is where an image is stored in a registry.
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+}
+
+// ParseReference parses `ref` as docker does: the first component is
+// the registry only if it looks like a host, the tag default to
+// latest and the official images of Docker Hub are under library/.
+func ParseReference(ref string) Reference {
+	r := Reference{Registry: DockerHub, Repository: ref, Tag: "latest"}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		r.Repository, r.Tag = ref[:i], ref[i+1:]
+	}
+	if i := strings.Index(r.Repository, "/"); i >= 0 {
+		first := r.Repository[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			r.Registry, r.Repository = first, r.Repository[i+1:]
+		}
+	}
+	if r.Registry == DockerHub && !strings.Contains(r.Repository, "/") {
+		r.Repository = "library/" + r.Repository
+	}
+	return r
+}
+
+func (r Reference) String() string {
+	return r.Registry + "/" + r.Repository + ":" + r.Tag
+}
+
+// endpoint is the base URL of the API of the registry, registries on
+// the local host are reached with plain HTTP, as docker does.
+func (r Reference) endpoint() string {
+	if r.Registry == DockerHub {
+		return "https://registry-1.docker.io"
+	}
+	host := r.Registry
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return "http://" + r.Registry
+	}
+	return "https://" + r.Registry
+}
+
+// client talks with the API of a registry, authenticating with the
+// credentials of the docker config when the registry asks for them.
+type client struct {
+	ref    Reference
+	user   string
+	secret string
+	// authorization is the Authorization header, once known
+	authorization string
+}
+
+func newClient(ref Reference) (*client, error) {
+	user, secret, err := Credentials(ref.Registry)
+	if err != nil {
+		return nil, err
+	}
+	return &client{ref: ref, user: user, secret: secret}, nil
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// authorize answers the challenge of a 401 response.
+func (c *client) authorize(challenge string) error {
+	if strings.HasPrefix(strings.ToLower(challenge), "basic") {
+		if c.user == "" {
+			return errors.New("The registry " + c.ref.Registry + " needs credentials, use docker login")
+		}
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.SetBasicAuth(c.user, c.secret)
+		c.authorization = req.Header.Get("Authorization")
+		return nil
+	}
+
+	params := map[string]string{}
+	for _, match := range challengeParam.FindAllStringSubmatch(challenge, -1) {
+		params[match[1]] = match[2]
+	}
+	if params["realm"] == "" {
+		return errors.New("Unknown authentication challenge from " + c.ref.Registry + ": " + challenge)
+	}
+	query := url.Values{}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	query.Set("scope", "repository:"+c.ref.Repository+":pull,push")
+	req, err := http.NewRequest("GET", params["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.secret)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("The registry " + c.ref.Registry + " refused the credentials: " + resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return err
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	c.authorization = "Bearer " + token.Token
+	return nil
+}
+
+// do sends a request to the registry, `path` is either a path of the
+// API or an absolute URL returned by the registry. The credentials
+// are sent only to the registry, not to the other hosts it points to,
+// like the storage of the uploads.
+func (c *client) do(method, path, contentType string, body []byte) (*http.Response, error) {
+	registry, err := url.Parse(c.ref.endpoint())
+	if err != nil {
+		return nil, err
+	}
+	target, err := registry.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(method, target.String(), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if c.authorization != "" && target.Host == registry.Host {
+			req.Header.Set("Authorization", c.authorization)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return resp, nil
+		}
+		resp.Body.Close()
+		if err := c.authorize(resp.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// expect checks the status of the response and closes it.
+func expect(resp *http.Response, status int, what string) error {
+	defer resp.Body.Close()
+	if resp.StatusCode == status {
+		return nil
+	}
+	message, _ := ioutil.ReadAll(resp.Body)
+	return errors.New("Impossible to " + what + ": " + resp.Status + " " + strings.TrimSpace(string(message)))
+}
+
+// pushBlob uploads the blob unless the registry has it already.
+func (c *client) pushBlob(b blob) error {
+	resp, err := c.do("HEAD", "/v2/"+c.ref.Repository+"/blobs/"+b.Digest, "", nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = c.do("POST", "/v2/"+c.ref.Repository+"/blobs/uploads/", "", nil)
+	if err != nil {
+		return err
+	}
+	location := resp.Header.Get("Location")
+	if err := expect(resp, http.StatusAccepted, "start the upload of "+b.Digest); err != nil {
+		return err
+	}
+	upload, err := url.Parse(location)
+	if err != nil {
+		return err
+	}
+	query := upload.Query()
+	query.Set("digest", b.Digest)
+	upload.RawQuery = query.Encode()
+	resp, err = c.do("PUT", upload.String(), "application/octet-stream", b.content)
+	if err != nil {
+		return err
+	}
+	return expect(resp, http.StatusCreated, "upload "+b.Digest)
+}
+
+// pushManifest uploads the manifest, or the index, under `reference`:
+// the tag of the reference of the client or a digest.
+func (c *client) pushManifest(b blob, reference string) error {
+	resp, err := c.do("PUT", "/v2/"+c.ref.Repository+"/manifests/"+reference, b.MediaType, b.content)
+	if err != nil {
+		return err
+	}
+	return expect(resp, http.StatusCreated, "push the manifest "+reference+" of "+c.ref.Registry+"/"+c.ref.Repository)
+}
+
+// Pushed is an image pushed to a registry.
+type Pushed struct {
+	Reference string `json:"reference"`
+	Digest    string `json:"digest"`
+}
+
+// Push builds `image` and pushes it to every one of its tags, with
+// the CA certificates taken from `certs`, if not empty.
+func Push(image Image, certs string) ([]Pushed, error) {
+	b, err := build(image, certs)
+	if err != nil {
+		return nil, err
+	}
+	return push(b.tags, b.manifest, nil, []blob{b.layer, b.config})
+}
+
+// PushIndex builds the images, one for each platform, and pushes
+// their index to every tag of the first one, as WriteIndex does.
+func PushIndex(images []Image, certs string) ([]Pushed, error) {
+	i, err := buildIndex(images, certs)
+	if err != nil {
+		return nil, err
+	}
+	manifests := []blob{}
+	blobs := []blob{}
+	for _, b := range i.images {
+		manifests = append(manifests, b.manifest)
+		blobs = append(blobs, b.layer, b.config)
+	}
+	return push(i.tags, i.index, manifests, blobs)
+}
+
+// push uploads `blobs`, then `manifests` by digest and at last `root`
+// under every one of `tags`.
+func push(tags []string, root blob, manifests, blobs []blob) ([]Pushed, error) {
+	pushed := []Pushed{}
+	// the tags of the same repository share the client and its token
+	clients := map[string]*client{}
+	for _, tag := range tags {
+		ref := ParseReference(tag)
+		key := ref.Registry + "/" + ref.Repository
+		c, ok := clients[key]
+		if !ok {
+			var err error
+			if c, err = newClient(ref); err != nil {
+				return pushed, err
+			}
+			clients[key] = c
+		}
+		c.ref = ref
+		for _, content := range blobs {
+			if err := c.pushBlob(content); err != nil {
+				return pushed, err
+			}
+		}
+		for _, manifest := range manifests {
+			if err := c.pushManifest(manifest, manifest.Digest); err != nil {
+				return pushed, err
+			}
+		}
+		if err := c.pushManifest(root, ref.Tag); err != nil {
+			return pushed, err
+		}
+		pushed = append(pushed, Pushed{Reference: tag, Digest: root.Digest})
+	}
+	return pushed, nil
+}
@@ -0,0 +1,373 @@
+package oci
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRegistry is a registry that keeps the blobs and the manifests in
+// memory and records the requests it receives.
+type fakeRegistry struct {
+	t      *testing.T
+	server *httptest.Server
+	// storage, when set, receives the uploads in place of the registry
+	storage *httptest.Server
+	// auth is the challenge of the registry: bearer or basic
+	auth string
+	// reject refuses every token, valid or not
+	reject bool
+
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string]string
+	requests  []string
+	tokens    int
+	// storageAuth are the Authorization headers received by storage
+	storageAuth []string
+}
+
+func newFakeRegistry(t *testing.T, auth string) *fakeRegistry {
+	r := &fakeRegistry{t: t, auth: auth, blobs: map[string][]byte{}, manifests: map[string]string{}}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+// withStorage makes the registry send the uploads to another host.
+func (r *fakeRegistry) withStorage() {
+	r.storage = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		r.storageAuth = append(r.storageAuth, req.Header.Get("Authorization"))
+		r.mu.Unlock()
+		r.upload(w, req)
+	}))
+	r.t.Cleanup(r.storage.Close)
+}
+
+// host is the registry as it appears in the references.
+func (r *fakeRegistry) host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+// login writes a docker config with the credentials me:pw for the
+// registry, as `docker login` does.
+func (r *fakeRegistry) login(password string) {
+	dir := r.t.TempDir()
+	auth := base64.StdEncoding.EncodeToString([]byte("me:" + password))
+	config := `{"auths": {"` + r.host() + `": {"auth": "` + auth + `"}}}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0644); err != nil {
+		r.t.Fatal(err)
+	}
+	r.t.Setenv("DOCKER_CONFIG", dir)
+}
+
+func (r *fakeRegistry) authorized(req *http.Request) bool {
+	if r.auth == "basic" {
+		user, password, ok := req.BasicAuth()
+		return ok && user == "me" && password == "pw"
+	}
+	return !r.reject && req.Header.Get("Authorization") == "Bearer secret-token"
+}
+
+func (r *fakeRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		r.mu.Lock()
+		r.tokens++
+		r.mu.Unlock()
+		if user, password, ok := req.BasicAuth(); !ok || user != "me" || password != "pw" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if scope := req.URL.Query().Get("scope"); scope != "repository:team/hello:pull,push" {
+			r.t.Errorf("wrong scope %q", scope)
+		}
+		w.Write([]byte(`{"token": "secret-token"}`))
+		return
+	}
+
+	r.mu.Lock()
+	r.requests = append(r.requests, req.Method+" "+req.URL.Path)
+	r.mu.Unlock()
+	if !r.authorized(req) {
+		if r.auth == "basic" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="fake"`)
+		} else {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+r.server.URL+`/token",service="fake"`)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case req.Method == "HEAD" && strings.Contains(req.URL.Path, "/blobs/"):
+		r.mu.Lock()
+		_, ok := r.blobs[req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]]
+		r.mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case req.Method == "POST" && strings.HasSuffix(req.URL.Path, "/blobs/uploads/"):
+		location := "/v2/team/hello/blobs/uploads/session?state=1"
+		if r.storage != nil {
+			location = r.storage.URL + "/upload/session?state=1"
+		}
+		w.Header().Set("Location", location)
+		w.WriteHeader(http.StatusAccepted)
+	case req.Method == "PUT" && strings.Contains(req.URL.Path, "/blobs/uploads/"):
+		r.upload(w, req)
+	case req.Method == "PUT" && strings.Contains(req.URL.Path, "/manifests/"):
+		body, _ := ioutil.ReadAll(req.Body)
+		reference := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		if strings.HasPrefix(reference, "sha256:") {
+			sum := sha256.Sum256(body)
+			if reference != "sha256:"+hex.EncodeToString(sum[:]) {
+				r.t.Errorf("manifest pushed under the wrong digest %s", reference)
+			}
+		}
+		r.mu.Lock()
+		r.manifests[reference] = req.Header.Get("Content-Type")
+		r.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// upload completes a monolithic upload, checking the digest.
+func (r *fakeRegistry) upload(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Query().Get("state") != "1" {
+		r.t.Errorf("the query of the upload location is lost: %s", req.URL)
+	}
+	body, _ := ioutil.ReadAll(req.Body)
+	sum := sha256.Sum256(body)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+	if digest != req.URL.Query().Get("digest") {
+		http.Error(w, "digest mismatch", http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	r.blobs[digest] = body
+	r.mu.Unlock()
+	w.WriteHeader(http.StatusCreated)
+}
+
+// testImage is an image of a fake executable with `content`.
+func testImage(t *testing.T, content string, tags ...string) Image {
+	exec := filepath.Join(t.TempDir(), "exec")
+	if err := ioutil.WriteFile(exec, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return Image{Exec: exec, Tags: tags, Port: 8080, User: "65532:65532"}
+}
+
+func TestPushBearer(t *testing.T) {
+	registry := newFakeRegistry(t, "bearer")
+	registry.login("pw")
+	image := testImage(t, "effe", registry.host()+"/team/hello:1.0", registry.host()+"/team/hello:latest")
+	b, err := build(image, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the layer is already there and is not uploaded again
+	registry.blobs[b.layer.Digest] = b.layer.content
+
+	pushed, err := Push(image, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Pushed{
+		{Reference: image.Tags[0], Digest: b.manifest.Digest},
+		{Reference: image.Tags[1], Digest: b.manifest.Digest},
+	}
+	if !reflect.DeepEqual(pushed, want) {
+		t.Errorf("pushed %v, want %v", pushed, want)
+	}
+
+	blobs := "/v2/team/hello/blobs/"
+	requests := []string{
+		// the challenge, then the same request with the token
+		"HEAD " + blobs + b.layer.Digest,
+		"HEAD " + blobs + b.layer.Digest,
+		"HEAD " + blobs + b.config.Digest,
+		"POST " + blobs + "uploads/",
+		"PUT " + blobs + "uploads/session",
+		"PUT /v2/team/hello/manifests/1.0",
+		// the second tag shares the client and its token
+		"HEAD " + blobs + b.layer.Digest,
+		"HEAD " + blobs + b.config.Digest,
+		"PUT /v2/team/hello/manifests/latest",
+	}
+	if !reflect.DeepEqual(registry.requests, requests) {
+		t.Errorf("requests:\n%s\nwant:\n%s", strings.Join(registry.requests, "\n"), strings.Join(requests, "\n"))
+	}
+	if registry.tokens != 1 {
+		t.Errorf("%d tokens asked, want 1", registry.tokens)
+	}
+	if _, ok := registry.blobs[b.config.Digest]; !ok {
+		t.Error("the config is not uploaded")
+	}
+	for _, tag := range []string{"1.0", "latest"} {
+		if registry.manifests[tag] != MediaTypeManifest {
+			t.Errorf("manifest %s has media type %q", tag, registry.manifests[tag])
+		}
+	}
+}
+
+func TestPushBasic(t *testing.T) {
+	registry := newFakeRegistry(t, "basic")
+	registry.login("pw")
+	image := testImage(t, "effe", registry.host()+"/team/hello:1.0")
+	if _, err := Push(image, ""); err != nil {
+		t.Fatal(err)
+	}
+	if registry.manifests["1.0"] != MediaTypeManifest {
+		t.Errorf("the manifest is not pushed: %v", registry.manifests)
+	}
+	if len(registry.blobs) != 2 {
+		t.Errorf("%d blobs uploaded, want the layer and the config", len(registry.blobs))
+	}
+}
+
+func TestPushRefused(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     string
+		password string
+		reject   bool
+		err      string
+	}{
+		{"wrong password", "bearer", "no", false, "refused the credentials: 401"},
+		{"token refused", "bearer", "pw", true, "Impossible to start the upload of sha256:"},
+		{"wrong basic password", "basic", "no", false, "Impossible to start the upload of sha256:"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registry := newFakeRegistry(t, test.auth)
+			registry.reject = test.reject
+			registry.login(test.password)
+			image := testImage(t, "effe", registry.host()+"/team/hello:1.0")
+			_, err := Push(image, "")
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("error %v, want %q", err, test.err)
+			}
+			if len(registry.manifests) > 0 {
+				t.Errorf("manifests pushed without credentials: %v", registry.manifests)
+			}
+		})
+	}
+}
+
+func TestPushRetriesOnce(t *testing.T) {
+	registry := newFakeRegistry(t, "bearer")
+	registry.reject = true
+	registry.login("pw")
+	image := testImage(t, "effe", registry.host()+"/team/hello:1.0")
+	if _, err := Push(image, ""); err == nil {
+		t.Fatal("push accepted with a refused token")
+	}
+	// every request is sent again once with a new token, then the 401
+	// is returned to the caller
+	b, _ := build(image, "")
+	requests := []string{
+		"HEAD /v2/team/hello/blobs/" + b.layer.Digest,
+		"HEAD /v2/team/hello/blobs/" + b.layer.Digest,
+		"POST /v2/team/hello/blobs/uploads/",
+		"POST /v2/team/hello/blobs/uploads/",
+	}
+	if !reflect.DeepEqual(registry.requests, requests) {
+		t.Errorf("requests:\n%s\nwant:\n%s", strings.Join(registry.requests, "\n"), strings.Join(requests, "\n"))
+	}
+	if registry.tokens != 2 {
+		t.Errorf("%d tokens asked, want 2", registry.tokens)
+	}
+}
+
+func TestPushUploadOtherHost(t *testing.T) {
+	registry := newFakeRegistry(t, "bearer")
+	registry.withStorage()
+	registry.login("pw")
+	image := testImage(t, "effe", registry.host()+"/team/hello:1.0")
+	if _, err := Push(image, ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(registry.storageAuth) != 2 {
+		t.Fatalf("%d uploads to the storage, want 2", len(registry.storageAuth))
+	}
+	for _, auth := range registry.storageAuth {
+		if auth != "" {
+			t.Errorf("the credentials of the registry are sent to the storage: %q", auth)
+		}
+	}
+	if registry.manifests["1.0"] != MediaTypeManifest {
+		t.Errorf("the manifest is not pushed: %v", registry.manifests)
+	}
+}
+
+func TestPushManifestsByDigest(t *testing.T) {
+	registry := newFakeRegistry(t, "bearer")
+	registry.login("pw")
+	tag := registry.host() + "/team/hello:1.0"
+	manifests := []blob{}
+	blobs := []blob{}
+	descriptors := []Descriptor{}
+	for _, content := range []string{"amd64", "arm64"} {
+		b, err := build(testImage(t, content, tag), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifests = append(manifests, b.manifest)
+		blobs = append(blobs, b.layer, b.config)
+		descriptors = append(descriptors, b.manifest.Descriptor)
+	}
+	index, err := newJSONBlob(MediaTypeIndex, Index{SchemaVersion: 2, MediaType: MediaTypeIndex, Manifests: descriptors})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pushed, err := push([]string{tag}, index, manifests, blobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pushed) != 1 || pushed[0].Digest != index.Digest {
+		t.Errorf("pushed %v, want the digest of the index %s", pushed, index.Digest)
+	}
+	for _, m := range manifests {
+		if registry.manifests[m.Digest] != MediaTypeManifest {
+			t.Errorf("manifest %s is not pushed by digest: %v", m.Digest, registry.manifests)
+		}
+	}
+	if registry.manifests["1.0"] != MediaTypeIndex {
+		t.Errorf("the tag has media type %q, want the index", registry.manifests["1.0"])
+	}
+	// the manifests by digest come before the index that points to them
+	last := registry.requests[len(registry.requests)-1]
+	if last != "PUT /v2/team/hello/manifests/1.0" {
+		t.Errorf("the last request is %s, want the index", last)
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want Reference
+	}{
+		{"hello", Reference{DockerHub, "library/hello", "latest"}},
+		{"team/hello:1.0", Reference{DockerHub, "team/hello", "1.0"}},
+		{"localhost:5000/hello", Reference{"localhost:5000", "hello", "latest"}},
+		{"ghcr.io/team/hello:1.0", Reference{"ghcr.io", "team/hello", "1.0"}},
+	}
+	for _, test := range tests {
+		if got := ParseReference(test.ref); got != test.want {
+			t.Errorf("ParseReference(%q) = %v, want %v", test.ref, got, test.want)
+		}
+	}
+}
@@ -1,7 +1,17 @@
 // Code generated by go-bindata.
 // sources:
 // effe/effe.go
-// effe/logic/logic.go
+// assets/docker/alpine.Dockerfile.tmpl
+// assets/docker/distroless.Dockerfile.tmpl
+// assets/docker/scratch.Dockerfile.tmpl
+// assets/harness.go.tmpl
+// assets/templates/form.go.tmpl
+// assets/templates/hello.go.tmpl
+// assets/templates/json_api.go.tmpl
+// assets/templates/reverse_proxy.go.tmpl
+// assets/templates/scheduled.go.tmpl
+// assets/templates/static.go.tmpl
+// assets/templates/webhook.go.tmpl
 // DO NOT EDIT!
 
 package sources
@@ -69,7 +79,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _effeEffeGo = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x53\x4f\x6f\xdb\x3e\x0c\x3d\xc7\x9f\x82\x3f\x03\xbf\x41\x0e\x0c\xa7\xc7\xa2\x43\x0e\x5b\x97\xfe\x01\x8a\x2d\x48\x30\xec\x58\xa8\x0e\xed\x08\x75\x64\x4f\xa2\x9b\x14\x59\xbe\xfb\x48\xc9\x0e\xda\xa1\x87\xc4\x92\xc8\x47\xbd\x47\x3d\x76\xba\x7c\xd6\x35\xc2\x4e\x1b\x9b\x24\x66\xd7\xb5\x8e\x40\x25\x93\xb4\x6a\x74\x9d\xca\x77\x47\xf2\xa9\x0d\x6d\xfb\xa7\xa2\x6c\x77\x33\x6f\x7c\x69\xf4\x0c\xab\x0a\x67\x4d\x5b\x9b\x52\xe2\xbc\x98\xf9\x57\xcf\x1f\xd9\x59\xa4\xd9\x96\xa8\x93\xb5\x7f\xb5\x9c\x91\x25\x09\xbd\x76\x08\x5c\xa0\x6b\xf0\x70\xdd\x5a\xc2\x03\x81\x27\xd7\x97\x04\xc7\x64\x52\xd2\x01\x42\xb5\x62\x88\x25\x13\x74\x0e\xf8\xd7\xba\xe4\x94\x24\x55\x6f\x4b\xa8\xd1\xa2\xd3\x84\x77\xda\x6e\x1a\x74\xaa\x6b\xdb\x06\xa6\x72\x43\xb1\xe4\x65\x2e\x15\x6a\x74\x72\x24\x54\x8a\x5f\xce\x10\xba\x0c\x04\xac\x84\x50\xb1\x42\xdf\xb5\xd6\x63\x8c\xe4\x30\x1d\x4e\x7f\xf7\xe8\x29\x13\x22\x0e\xa9\x77\x36\x42\xf6\xf0\x21\xc8\x7d\x00\x0b\x02\xae\xe6\x20\x94\x8a\x5b\x24\x95\x15\xea\xbd\xd8\x8c\x93\x36\x58\x31\xbd\x50\x3b\xa2\x26\xa6\xe2\x72\x8c\x73\x58\xb6\x2f\x2c\x29\xfb\xcc\xfb\xff\xe6\x60\x4d\x13\x13\x26\xfb\x28\xe3\x0e\xf5\x86\xe3\xe1\xe2\x35\x69\xea\xfd\x3d\x97\x75\x56\x37\x6b\x74\x8c\x5c\x48\xa7\xb2\x80\x88\x5d\x28\xae\x19\xa6\xd2\x07\x69\x2a\x2c\xb5\x35\xe5\x33\x6e\xd2\x90\x71\xe2\xbf\x93\x92\xa5\xf4\x98\x6f\x8f\x9d\x5f\xf5\x56\xb1\x8c\x82\x7f\x39\xc8\x82\xa3\x39\xec\x59\xb0\xa4\x32\x53\xc9\x7e\xcb\x6d\xb8\xe8\x1b\x3e\xf5\xb5\xe2\x60\x11\x48\xa8\x4c\xd2\x4f\x11\x32\x94\x81\xf9\x1b\x58\xe8\xd1\xb2\x27\xb9\x6c\x48\x3d\x9d\xdf\x58\x9c\x18\x9b\x13\xbc\xc8\xe4\xc4\x8a\x05\x8b\x55\xa9\x9c\xa4\x39\x5c\x5e\x5c\x5e\xe4\x90\x2e\x25\xbe\xdf\xa2\x43\xf0\xd2\x02\xa0\x2d\x82\xd8\xb2\x10\x95\xc6\x56\xed\x19\xfd\x95\x6f\x54\xa9\x1c\x31\xbc\xd2\x8d\x47\xc1\x3b\x63\xe9\x0c\x02\x89\xba\x9d\x26\xd3\xda\x5c\x4e\x2d\xe0\xc1\x50\xa8\x15\x6a\x2c\xb5\xf3\x28\x4d\x63\x55\xd3\x50\x5d\xc4\xf0\x7c\x14\xa1\x50\x63\x55\xec\xe2\x3d\x87\x44\x55\x34\x92\x48\x9b\xf4\xae\x09\x54\x38\x77\xdd\x49\x72\xa5\xd2\xab\xff\x37\xcc\x65\x2a\x92\x38\x7b\x84\xf2\x8b\xc5\x5d\x2d\x46\x7b\x14\xd4\xe0\xe5\xef\xb8\x57\xc3\xf2\xe1\xc7\xed\xe3\x62\xb5\xfa\xf3\x66\xfb\x73\xbd\x58\xb1\x24\x7e\x6c\x0f\x37\xae\xdd\xc1\x42\x24\x09\xf7\x17\xed\xe4\x11\x64\x3e\x60\x0e\x9f\xce\xc3\x72\xe4\x82\x57\xa3\x15\x8d\x58\xa9\xd2\x25\x1e\x4f\xa3\x99\x73\x78\xe7\x0d\xb6\x9c\x0b\xdc\xc6\x01\x79\x6f\xee\xe3\x88\x90\xb7\xe4\x5f\xb0\x69\x1c\xd3\x1b\xb9\x22\x9d\xb1\xd8\x7f\xc7\x77\xa0\x35\x8e\xad\xd8\x26\xe0\x1e\x8c\x27\xb4\x5f\xec\x26\x38\x5b\x71\xf7\x72\x31\x4f\xc6\x1e\xf9\x1b\x00\x00\xff\xff\x68\x0a\x0d\x0a\xb0\x04\x00\x00")
+var _effeEffeGo = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd4\x7b\x6d\x73\x1b\xb9\x91\xf0\x67\xf2\x57\xf4\x4e\x55\x9c\xa1\x76\x34\xf4\x3a\x4f\xf2\x6c\xe8\xe3\x07\xad\x2c\xaf\x95\xb5\xb5\x2a\x49\xce\x5e\x2a\x97\xb2\xa0\x99\x1e\x12\xa7\x21\xc0\x00\x18\x49\x3c\xaf\xfe\xfb\x55\x03\x8d\x79\x21\x29\xd9\x7b\x57\x77\x55\x97\xd4\x5a\xe4\x0c\xd0\xdd\x68\xf4\x7b\x37\xa7\xd3\x85\x9e\xdd\x34\xb2\x2e\x41\x2e\x94\x36\x38\x1e\x4f\xa7\x70\xb5\x44\x28\xb4\x41\xd0\x15\xe0\x1d\x9a\x0d\x60\x55\x61\xe6\xff\x3d\x74\x5a\xd7\x80\xab\x1b\x2c\x2d\x48\x07\x52\x81\xd5\x8d\x29\xd0\x4e\x6f\xa4\x2a\x85\x13\xf9\x42\x13\x10\xa1\x4a\x28\xf4\x6a\x2d\x6b\xa4\x85\x19\x28\xb1\x92\x6a\x01\x6e\x89\x50\xc9\x1a\x33\x70\x7a\x81\x6e\x89\x06\xee\xa5\x5b\xfa\xe7\xb5\x5e\xc8\x82\xb0\xba\x25\x12\x0c\x42\x08\xc2\xc2\x42\xba\x65\x73\x93\x17\x7a\x35\xb5\xd2\x16\x52\x4c\xe9\xcd\xd4\x2f\x7f\xed\x77\x86\x33\x14\x5a\x59\x67\x84\x54\x0e\x6e\x11\xd7\x84\x97\xc0\xe8\xc6\x31\x50\x58\x8b\xe2\x56\x2c\xd0\xd2\xf7\xf6\x38\xf9\x78\xcc\xcf\x61\x25\xa4\x1a\x8f\xe5\x6a\xad\x8d\x83\x74\x3c\x4a\x0a\xad\x1c\x3e\xb8\x84\x3e\x9a\xcd\xda\xe9\xa9\xab\x6d\xef\xdb\xc3\x1f\x5f\xfe\x99\xbe\xa2\x2a\x74\x29\xd5\x62\x7a\x23\x2c\xfe\xe9\xff\x0d\x1e\xfd\xbb\xd5\xca\x3f\x30\x46\x1b\xbf\xb9\xaa\xc5\xc2\xff\x5d\x79\xc8\xcf\x9d\x8f\xde\x4b\x1d\xfe\x9d\x4a\xdd\x38\x59\xd3\x97\x5a\x2f\xf8\xcf\xd4\x6e\x2c\x7f\x53\xe8\xf8\xcf\x74\xe9\xdc\xba\xff\xd9\x3f\x70\x68\xfd\x02\xed\x89\xd0\x76\x6a\xe5\x42\x09\x0f\xcf\x3a\x53\x68\x75\xc7\x1f\xa5\x5a\xf8\x25\x76\xa3\x8a\xf0\xd7\x16\xa2\xf6\x0b\x9d\x5c\x21\xfd\x6d\x94\x2c\x74\x89\xd3\xc6\x55\xdf\x27\xe3\x89\x17\x1c\x12\x9a\xbf\xa2\xb1\x52\x2b\x90\x25\x2a\x27\x2b\x89\x16\xdc\x52\x5a\x2f\x50\x7d\x11\xb2\xe8\xe8\x7e\x40\x38\xbe\x3c\x02\x9c\x8f\xef\x84\x19\x80\x99\x43\x52\xe2\x5d\xe2\xc1\xaf\x8d\xbe\x43\x25\x54\x81\x20\x2d\xdc\x2f\xd1\xa0\xbf\x7c\x7c\xc0\xa2\x71\xe2\xa6\x26\xb1\x5d\xa1\x85\xca\xe8\x55\xe6\x5f\xd9\xa5\x78\xf5\xc7\x3f\xf5\x44\x2a\x08\x6b\x78\xb9\x90\x0e\x0c\xde\x49\x8f\x88\xe4\x95\x1e\xde\x31\x62\x16\x18\xa2\x85\x44\xf0\x2f\x97\x3f\x9f\x81\xa8\x1c\x1a\xa2\x44\xc0\x4a\x98\x5b\x34\xaf\xbf\x74\x20\x0f\xf6\x5a\x2a\xbb\xc6\xc2\x5d\x43\x25\x55\x54\x1b\x02\x33\x24\x3e\x1c\xbe\x77\xc8\x39\x24\xe1\xe0\x4b\x14\xb5\x5b\x9e\x0b\xb7\x04\xa1\xec\x3d\x1a\x0b\xaf\x5e\xbe\x84\xfb\xa5\xac\x99\x03\xa4\x28\xd2\x82\x45\x73\x27\xd5\x22\xf3\x28\x2c\x34\x16\x4b\xb8\xd9\x10\x88\xeb\xc3\xb5\xd1\x37\x78\xed\x09\xba\xd9\xf8\x5d\x01\x6c\xb1\xc4\xe2\xd6\x46\x05\x21\x79\x17\x52\xa1\xb1\xf9\xd8\xab\x53\x1f\xf9\x1c\x92\xe9\x27\x3a\xf1\x34\x3c\x4c\xc6\xe3\xaa\x51\x05\x2f\x79\x27\x54\x59\xa3\x49\xef\x81\x64\x2d\xbf\x40\xbb\xd6\xca\xe2\x2f\x46\x3a\x34\x19\x18\x38\xe0\xe7\xff\x6c\xd0\xba\x09\x7c\x1e\x8f\xee\xf3\x77\x28\x4a\x34\xe9\x24\xbf\x44\x97\x26\xc7\xa4\x6d\xca\x1d\x5e\x6d\xd6\x98\x64\x90\x90\xea\x4d\xd7\xb5\x90\xea\x35\x14\x4b\x61\x2c\xba\x79\xe3\xaa\xc3\xef\x93\xc9\x78\x24\x75\xee\x61\x5f\x3a\x23\xd5\x22\xbd\xcf\x20\xd1\xb7\xff\xa6\x92\xc9\xf8\x31\x8a\xcb\x0d\x02\x1f\xaf\x3b\x6f\x3c\x29\x9d\x03\x6a\x69\x1d\x2a\xb2\x4b\x5a\xc1\xb5\x28\x4b\x83\xd6\x5e\x67\x20\xac\x87\x20\x2c\x71\xd0\x69\x38\x0c\x0b\xb3\xb8\xb9\xd6\x85\xa8\x61\xa9\xad\x7b\x4d\xbc\xae\xe9\xee\xe9\xb9\x5c\x79\xeb\x42\x16\x4d\x37\x8e\x60\x14\x8d\xa9\x61\x29\xee\x10\x04\x13\xe0\x29\xca\xe9\xdd\xcf\x77\x68\xe0\xea\xfd\x65\xe0\x3c\x1a\xd2\x97\x42\x38\x7f\x95\x4a\x3b\xb8\x43\x43\x1a\x54\x06\x71\xf5\x17\x08\x5a\xd5\x1b\x70\xa2\xa6\x33\xe9\x28\x44\x1d\x39\x39\xfc\x42\xd6\x74\xd5\xb8\x46\xd4\x1e\xb6\x74\xb0\x36\x68\x51\x39\x0b\xd7\x1e\xc6\x31\x1a\x77\x9d\x81\x36\x51\x29\xfa\xa8\xfb\xdc\xb9\x5f\xa2\x62\x51\x22\x72\x2c\xba\x99\x7f\x59\xd4\x12\x95\x83\xe3\x23\x58\x35\xd6\xf9\xd3\x11\x18\xb2\x26\x58\xd2\x06\x12\x32\xe9\xc2\x5b\x51\xd7\xfa\x3e\x6e\x11\x8d\x5b\x92\x5d\x28\x84\x23\xa5\x4b\xf1\xc1\xa1\x2a\xb1\x84\x5b\xf4\x62\xda\x58\xb2\xc2\x61\xf1\x51\xe3\x96\x13\x3a\xba\x20\xd4\x86\x78\xd5\x23\x94\x24\xd6\xa1\x82\x52\xab\xdf\xbb\x3c\xba\x2d\xab\x8b\x5b\xba\x0b\xbe\x39\x16\xf4\xb5\x30\x44\xef\xda\xe8\x02\xad\xa5\x83\xdf\x6c\xc0\x6e\xac\xc3\x55\x09\x85\x50\xbf\x77\x70\xe3\xad\x83\x41\x51\x2c\xb1\xf4\x06\x04\x84\xd2\xde\x3d\xf1\xb6\x3c\xc8\xba\xe7\x60\xca\x92\x92\x81\xab\x2d\xb1\xd3\x7f\xf8\x09\x37\xfe\xef\xb1\x27\xff\xf8\x28\x83\x96\xdd\xfc\xf1\x27\xdc\x40\x30\xaf\x13\xf0\xde\x80\x94\xc0\x19\xa1\xac\x77\x38\xb3\x39\xbc\xf0\x3a\x72\x15\x1f\x7d\x7e\x1c\x8f\xe8\x5a\x61\x36\x07\xc6\x39\x1e\xd9\x7b\xe9\x8a\x25\x6d\x2d\x84\x45\x06\x68\xf3\x77\xc2\x9e\x1b\xac\xe4\x43\x47\x5d\xd2\x28\xf9\x30\x4b\x26\xb3\xf1\x68\xb4\x26\x0d\x9e\xcd\xdb\xe5\x57\x46\xae\x9e\x5a\x3f\x1e\x75\x54\xe5\x6f\xa4\xa8\xbd\x62\x3e\x38\x98\x03\x31\x21\x2d\xdc\x03\xb0\x67\xcc\xf9\x55\x06\x9f\x32\xf8\xc4\xd0\x27\x90\x2a\xf4\xaf\x54\x16\x0e\xea\xd5\x7d\x34\x22\x0b\x57\x02\xbd\x23\xa8\x68\xc6\xa3\xd1\xc8\xa0\x6b\x8c\x82\xb2\x8f\x88\x30\x30\x39\x49\x06\x44\xfa\x64\x3c\x1a\x3d\x8e\x47\x81\x1b\x73\x48\xbc\xc8\xd3\x97\xe4\x2b\xd8\x50\x95\xb3\x64\x92\x45\x0e\xc2\x7c\x0e\x09\x5f\x7f\x32\x1b\xb7\x14\x78\x42\x6d\x7e\x86\xf7\x69\x72\xba\x5a\x6b\x6b\x25\xf9\x14\xa7\x59\xf5\x5c\x2b\x60\x90\xc0\xb7\x2d\xb4\x6f\x21\xd9\x2b\x30\x7c\xe7\x3d\x75\x22\xcd\x3c\x24\x9e\x92\x08\xb2\x49\x01\x7f\x45\xde\x2c\xbb\x62\x3d\xcb\x89\xf9\x25\x56\xa2\xa9\xdd\x8c\x8f\xab\xc4\x0a\x33\xa0\x7d\x9e\x99\x24\x0b\xc4\xc1\xcb\x75\x2d\xdd\x3b\x6d\xdd\xb9\x36\x2e\x7d\xf6\x5a\x09\x72\x32\x21\x16\xca\xca\x83\xf8\x66\x0e\x4a\xd6\xf0\xb9\xc7\x7f\x34\x86\x59\x2c\x2b\x90\xeb\x88\xe5\x9c\xac\xee\xe9\x79\x1a\x09\x99\xbc\x86\xf8\xd1\xf3\x31\x81\x5f\x7f\x85\x54\xae\x23\xc8\x17\x2f\x40\xae\xf3\x53\xfb\xd1\xfb\x3c\x6f\xbe\xd2\x09\xdf\x7e\xb7\x11\x92\xef\x5e\xfd\xff\xfc\x65\xfe\x32\xff\x2e\x19\x5e\x2c\xe1\xfc\x8b\x96\xaa\x3d\x58\xdc\x14\x38\x30\x19\xd3\x62\x5b\x2c\x71\x85\x44\x62\xc2\x81\x8e\xac\xa2\x2a\x12\x21\x49\xe2\x11\xf2\x32\x5e\x65\x93\x81\x58\x5f\xbd\xbf\x64\x45\xd5\xaa\x92\x0b\x98\xc3\x0b\x57\x5b\x92\xd9\x4a\x2e\x3e\x9f\x2a\x8b\x45\x63\xf0\xf2\x56\xae\xff\x4a\x66\x78\x33\x03\x67\x1a\x64\xfe\xf4\xb4\xbc\x87\x6f\x24\xab\x4e\xe5\xfb\xcf\x47\x5b\x66\x02\xe6\xfb\x4c\x03\xad\x24\xf8\xa3\x9e\x99\x6b\x6f\x9c\x68\x7b\xaf\x45\xf9\xaf\x7f\x7c\xf9\xe7\x9f\x70\x73\x2e\xa4\x49\xb7\xa0\x4e\xc6\xa3\xbd\x17\x3c\xbc\x61\xe2\xdf\x33\x7c\xc8\x8f\xfb\x36\x76\x0e\x7f\xff\x07\x61\xee\x3d\xfc\xdc\xa3\x8e\x40\x3d\xfa\x1b\x61\xfb\x3e\x9b\x07\xe7\x1f\x38\xfb\xb9\xb5\x63\x33\x68\x11\x66\x70\x25\x57\xa8\x1b\x37\x83\x3f\xc0\x81\x8f\x92\xf2\x4b\x2c\xb4\x2a\x1f\xc7\x23\x83\x76\xdd\x1e\x39\xc0\xcc\x7f\x44\x97\xf2\x4d\x7e\x0b\xc9\x6c\x3a\x25\xe5\x23\xa9\x80\x6f\x7b\xc1\xc9\x64\xbc\xe7\xe8\xfd\x83\x33\xf4\xfc\x07\x5d\x6e\xf2\xe3\x5a\x5b\x4c\xc3\x1e\xc2\x99\x5f\x3a\xe1\x1a\x7b\xac\x4b\xa4\x7b\xf3\x67\x08\x8f\x7e\xfe\xa9\x0f\xa9\x5a\xb9\xfc\x84\xcc\x44\x95\x26\x8d\x0a\xfe\x7c\x33\x83\xdf\xd9\x24\xeb\xc3\x99\x30\x3a\x8f\x5d\xc9\x9a\x22\x12\xb7\x59\xfb\xf8\x74\x5d\xe3\x03\x5b\x39\xb2\x59\x4d\xe1\x08\x01\x59\x54\x9f\xcf\x44\x7b\x3a\x1e\xd1\x59\xbc\x49\xa2\xdd\x64\x76\x61\x81\x0a\x8d\x70\x18\x23\xad\x35\x05\xd2\x07\x14\xa4\xe7\xe7\x5a\xd7\x19\xd4\x7a\xb1\x40\x03\x07\x21\x25\x08\xe1\x91\x99\x04\x9b\xbd\x37\x28\xdb\x0d\xc9\xe2\x41\x69\xcb\x6f\x88\xe4\xfc\x01\x66\x73\x20\x92\xfc\x8d\x4d\xf2\x74\x78\x58\x92\xce\x12\x2b\x34\x01\x36\x9b\x04\x59\x81\xbf\x6b\x83\x85\xbe\xa3\x40\xf0\x35\x6c\x09\xef\x7d\x38\x06\x07\x8a\xbd\x9b\x39\x55\x0e\x8d\x12\xf5\xa5\x8f\x0d\xfc\xad\x10\x8e\xd1\x28\x70\x21\x3f\x36\xd2\xa5\xc9\x7b\x62\x2a\x9c\x0b\x25\x8b\x5b\x2c\xc9\xba\x7a\x91\x1d\x3d\xd2\xed\x8f\x58\xd2\x02\xe7\x2f\x1a\x45\x7e\x27\xf7\xbe\x87\x3e\xa0\x31\x19\xdc\x67\x60\x9e\xb0\x9c\x8c\xe8\x0d\xde\x34\x8b\x14\x8d\x09\xa2\x91\x4e\xa2\xaf\x92\x55\x04\x03\xf3\xde\x36\xcf\xa3\xf3\xc6\x11\x32\x5e\xfa\xc8\x31\x6b\x4f\xb7\x2e\xb0\xd6\x74\x64\x4e\x65\xc9\x8b\x50\x38\xd7\x5b\x41\xc9\xf7\x0a\x57\xda\x6c\xc8\x7d\x70\xfc\x12\xf2\x0b\xb1\x10\x52\x05\xaf\x54\x4a\x7b\xcb\x69\x3c\xe9\x5a\x0c\x25\x7d\x24\x64\xb0\x40\x79\x87\x16\x04\xed\xbe\x3c\xfd\xf1\xdd\xc7\xf3\x0c\xac\x0e\x41\x57\x0f\x95\xa5\x30\x09\x6e\x10\x8c\x76\xc2\x61\x19\x23\x5c\x12\x7a\x27\x8c\x93\x6a\xd1\x26\x34\x55\x85\x39\x4b\xfb\x9e\xd3\xf4\x44\x1e\x8d\xf3\x39\x4d\x70\x5d\xe3\xd1\x2d\x99\x35\xb7\x04\x76\xe5\xe3\xd1\xaa\x81\xf0\x3f\x2f\xe2\x17\xbf\x7c\x68\x1c\x3e\x84\x8d\xf4\x14\x0e\xb6\x4c\x53\xab\x28\x0a\xef\x8f\x77\x71\xa7\x11\x63\x06\x11\x55\x1b\xae\x1c\x14\xbb\xeb\xfb\xa1\x4b\xe1\xa5\xf4\xc5\x9e\x55\x9f\x23\xd4\x19\xec\xc0\x9f\xc5\x0f\x8f\xad\x75\x22\xbb\x66\x72\xe3\x37\x93\xb4\xef\xb7\x58\x4a\xd6\x59\xcf\x6c\x79\x95\x2c\x4c\x16\x6d\x89\x3f\x64\x5a\x18\xd8\x47\xf6\x04\x22\xf4\x2e\xc6\xa4\x65\xcf\xba\x92\xc2\xe4\x1d\xf5\x85\xc9\x99\xee\xaf\xb1\xaa\x85\xc9\x57\x4d\xfe\x5e\x17\xb7\xa4\x52\x0c\x08\x98\x59\xf1\xf5\x47\x55\xf3\x82\xa1\x55\x9c\x4e\xe1\x5e\xb8\x62\x79\xe9\x2b\x11\x4c\xb9\xdd\x49\x88\xb4\x62\x11\x8e\x32\x4a\x3e\x78\x89\xa0\xf0\x7e\x4b\x6d\xda\xdc\x49\xd4\x32\x64\xf4\xba\x2e\x41\x2b\x9f\x54\xdd\xe2\xda\xe5\x5f\xe2\x5e\x8f\x9e\x60\xa5\x96\x8d\x8f\x86\x56\xe2\x16\xd3\x62\x29\x14\x68\x9b\x07\x7a\x33\xf8\x6e\x32\x1e\x51\xde\x23\xea\xfc\x4c\x3b\x59\x6d\xd2\x65\xb3\xce\x80\x0b\x26\x79\x20\x77\x32\x1e\x2d\x74\xdf\xec\x55\xda\x80\x11\x6a\x81\x40\xb0\xa3\x21\xfc\x0a\xf1\xf0\xc6\x2d\x3f\x37\x52\xb9\x5a\x6d\x47\xa9\x61\xd7\x36\xef\x48\xd8\x71\x1d\x4b\x6f\xcc\x8c\x99\x8f\x60\xb7\x4c\xd6\x68\x54\x68\xe5\xa4\x6a\xb0\x0d\x13\x06\xd8\x7a\x2a\xc5\xb8\xb0\x0c\x80\x7a\xd2\x13\x0d\x5a\x3a\xf9\x0a\x41\xfd\x11\x5d\x0f\x68\x1a\xf4\xd9\x7b\xfd\x77\x58\xd7\xfa\x54\x55\x9a\x74\x73\x4b\xcd\x87\x7a\x49\xd2\x75\x11\xa5\x2f\x38\x18\x7e\xb8\x23\x73\x4c\x66\xab\x46\x64\xb1\x6a\x1b\xe2\x1e\x28\x0c\x7a\x43\xc7\x55\x90\x4a\x2e\x1a\x13\xb2\xd4\x86\x8b\x00\x3e\x09\xed\xc2\x79\xf2\x57\x24\x7c\x57\xef\x2f\xbd\x3c\x5e\x17\x1c\x11\x5e\x47\x19\xc4\xd5\xda\x6d\x7a\x19\xb3\x05\x61\x10\x0c\xfe\xb3\x91\xc6\x83\xa4\xed\x9c\x9c\x83\x18\x48\x31\xe7\xd2\x37\x1b\x2f\xba\x9c\x94\x1f\x1f\x59\xb2\xf9\x04\xf0\xa6\xa1\x10\x80\x65\xb9\x3d\xc4\x53\x9c\xce\x98\x80\xe3\x23\xb6\xad\x2d\x57\xfd\xb6\x01\x43\xfd\x13\x98\x0d\xc3\xe1\xf1\x68\x34\xbc\xab\x19\x89\xe9\xf0\x51\x36\x1e\x8d\x3e\x48\xc5\x95\xbb\x19\x19\x68\x62\x6f\xce\x0f\xae\xde\x5f\x7e\xf7\x2a\xf3\x81\x91\xac\x3a\x7a\xe6\x6d\x94\x1c\x2f\x89\x49\xa2\x3b\xa2\xc5\xe1\xa4\xad\xf1\x0a\x25\xd0\xfc\x02\x45\xf9\x56\xd6\x98\x46\x40\xcf\x59\xaa\x81\x35\x25\xef\x4b\xc7\xa3\xca\x2d\xe5\x7a\x24\x58\x14\x3d\x71\x34\xf8\x0d\xbd\xcf\x8f\xd6\x6b\x54\x25\xbd\xb2\x6f\x8d\x5e\x9d\x9f\x7c\x48\x03\x1d\x93\x7d\x70\xdb\xac\xf1\x4c\xb3\xdd\xe9\x5f\x25\xdf\x18\x47\xc8\xc7\x47\x7c\x77\xac\x39\x1d\xf9\x8f\x91\xf9\xac\x02\x74\xdb\x21\xa0\xda\x7a\x41\x85\x11\x98\x7b\xde\x52\xe4\x26\x0d\x1e\xa9\x32\x24\x29\xbc\xd3\x1b\xde\x3d\xfc\x0c\x32\xcf\xa9\xef\x5b\x69\xac\x7b\x5b\x92\xb0\x92\x44\x55\xf4\xd5\x97\xe6\xa1\x44\x5b\x18\xb9\x76\xda\xc4\x12\x62\xdc\x43\xdb\x29\x1f\x16\xd6\x72\x2a\x4c\x65\x28\x7f\x3e\x51\x38\x79\xe7\x63\x03\xd2\x13\x59\x60\x2c\x20\x6e\xa1\x9b\xc3\x1f\x3c\x15\x9c\xfe\xf6\xd5\x2e\x3c\x42\xc3\x04\xdc\x04\x05\xe8\x95\xe5\x68\x91\x28\x0a\x5c\x3b\x2c\x09\x46\xa5\xcd\x4a\xb0\x5a\x5d\xfb\x44\x7a\x4a\xb5\x82\xa9\xd3\x39\x51\x77\x9d\xc1\x35\xa5\xbe\x94\x2c\xcc\x28\xe9\xa3\x07\x55\x39\x3b\xbb\x8e\x01\xd3\x35\x13\x77\x9d\x51\x35\xb5\x58\xd2\x79\xfb\xec\xe0\x74\xbf\x2b\x27\xf1\x7a\x1f\xff\x10\x05\xbc\x80\x0f\x2f\xb5\xf2\xa5\xa8\x23\xd5\x56\x07\x62\xa0\xb4\xf6\xa5\x16\x62\x36\x71\x45\x96\x48\x16\x40\xc0\xd5\xf1\x79\x5c\xca\xca\x1c\x98\x10\x93\xf7\x4e\x5f\x29\x31\x7e\xcf\x0c\xea\x2b\xec\xff\x4a\x35\xc8\xc7\x96\x2b\x7d\x17\xdd\x08\x1f\xbb\xc6\x8a\x4a\x67\x4b\x19\x8a\xca\x82\x2a\x8d\x77\x52\x37\x16\x4c\xa3\xba\x98\x79\x36\x27\x97\x79\x81\x2b\x7d\x87\x29\x61\x1e\x3a\xb6\x17\x2f\xe0\x1b\x6d\xf3\x53\x7b\xa6\xdd\xc9\x83\xb4\x8e\x62\x69\xce\x0f\xb6\x34\x8d\x03\xeb\xf8\xb4\x65\x49\xba\x55\x2a\xfa\x22\x2b\x48\x2c\x02\x27\xf6\xc0\x72\xc5\x3a\xc9\xe0\xeb\x2a\x2a\x5f\xc4\xe4\x6b\x4f\x84\xa8\x2a\x5b\x23\xc6\xdd\x96\xfc\xc8\x69\xf9\x7c\xe5\xc6\xef\x7e\x22\xfd\xd8\xe2\x4d\x57\xbb\x52\xc1\x08\x6d\xe9\xf2\xac\x5f\xb5\x62\x37\x1d\x4f\x4f\x4b\xa3\x70\xa5\x55\x19\xcf\xf5\x85\x82\x19\x3f\x6a\x37\x0e\xd3\xde\x1d\x86\xb6\xa8\x07\x66\x28\xee\x86\xb0\xef\x6b\xb4\x2f\xa3\xed\x95\xa6\xc2\x72\x14\xc8\xf7\xa7\x97\x57\x27\x67\x9f\xce\x4f\xdf\x4c\xf9\xe3\xdb\x37\x97\x94\xe7\x38\x5d\xe8\x9a\x55\x6b\x0b\x63\xfa\x8c\x56\xad\xe5\x13\xb7\xa5\x2d\xa5\xb7\xa8\xee\xd2\xa4\xc3\xe9\x25\x61\x78\x43\xbf\xfe\x0a\x6b\x59\xd2\xb7\xb0\x63\x2d\x4b\x0e\xfe\x9e\xba\xb6\x33\xfd\xf4\x81\x7b\xe7\x8b\xc1\x85\x45\x07\x14\x47\xfa\xee\x1a\x27\x74\xbe\x7a\xf8\x38\x1e\x55\xa5\xfd\x5a\xea\xdf\xbe\xb9\xdc\x4f\x7d\x55\x5a\xf8\x17\xf8\xee\xbf\x4d\x32\xdd\x43\x47\x72\xa4\x50\xdb\xfc\xa3\xb2\x7b\x18\xb9\xff\x95\xa7\xf2\xa9\x57\x67\x47\x1f\x4e\xfc\xeb\x7d\xc2\x3c\xf4\x3e\x5d\x50\xba\x25\xf1\x20\x95\x7b\x46\x1c\x2a\xb6\x61\x67\x78\xef\xa3\x8e\x46\x2a\xb7\x76\x5e\x55\x58\x49\xbf\x8d\x7c\x3e\x75\x5a\xd0\xf3\x36\x1a\xad\xba\x6a\x53\xe4\x23\xba\xfc\xed\x00\x7f\xd4\x09\xa9\xee\x74\xe8\x8d\x70\x71\x25\x7a\x67\xc3\x5f\x29\xc3\xa7\x18\x50\x2b\x3c\xb4\x4b\xed\x60\xa5\x4b\xea\xa5\x87\x46\x4e\xdc\xc4\xf1\x62\xa1\x57\x2b\xad\xb6\x9b\xdd\xdc\x2a\xb9\xd1\xe5\x86\xa0\xfb\xf6\x1a\x50\x85\x06\x1a\x55\x93\xb6\x5f\x4b\xfb\x83\xef\x62\x9f\x50\x53\x1b\x4b\x1f\xcf\x5a\x74\x9c\xd5\xef\xd2\xd8\xe5\xf4\x1f\xd0\x2d\x75\xc9\x59\x3b\xc4\x4c\x3e\x7e\x0b\xff\xbf\xa6\x7e\xf8\x2c\x59\xf9\xa5\xc9\xf5\x78\x44\x49\x67\x7c\xf9\x85\x4d\xe4\x3c\x68\x4b\xa8\x06\xd9\xf8\x16\x56\x62\xfd\xf7\xb0\xed\x1f\x7f\xff\x07\xef\xe7\x2d\xcb\xb0\x94\x76\x51\xe9\xef\x2b\x11\x11\x77\x68\xcb\xe9\x90\x15\x70\x43\xd1\x23\xec\xdd\xb2\xc5\xb5\xe4\x7a\xcf\x9d\x86\x96\x68\x77\xa9\xfc\xfd\xde\x48\x47\xbd\x80\x67\x2e\x96\x57\xfe\x96\x9b\x45\xa6\x59\x2a\x08\x53\x09\xa1\x79\xb8\xd5\xd5\x0b\xbe\xe2\xe3\xd5\xdb\xc3\xef\xf7\x5c\x30\xa3\xed\x6e\x38\x14\x38\xe3\xd1\x81\xf4\x26\x7e\xdc\x66\x88\xf5\x4b\xff\x4f\x5d\x97\x77\x13\xa9\x34\x03\x1e\x70\x95\x93\x4a\x8f\xfc\x85\x3c\xc7\xa0\x06\xda\xb7\x15\x9e\xfd\x33\xaa\x9c\xdf\x6c\x1c\xa6\xd2\xf8\x92\x73\x30\xb1\xd2\xe4\xdb\x24\x92\x7d\xa5\xb6\x58\x57\xee\xf5\xce\x9e\xa0\x78\xa8\x30\xe7\xdb\xcb\x2f\x5d\x79\xc2\x33\x25\xf9\x1b\xa4\xdd\xdc\xfa\x8e\x28\x76\xcb\x05\x5b\x86\x3b\x16\x1b\x99\x14\xd6\xd6\x2e\x05\xeb\x3d\x83\xe4\xc7\x93\xab\xa4\xb7\xd8\x6b\xe9\x60\x69\x3b\x13\x10\x96\x99\xd6\xe5\x78\xce\x9c\xe1\x7d\xe4\x56\x0b\x36\x8b\x80\xba\xe8\xca\x2f\x23\xe9\xe0\x38\x28\xa5\x83\x4f\x76\x3d\xd2\x1e\x37\x14\xb0\xc6\x2b\xf8\x78\x71\x0a\xf3\x08\x3f\x3c\x5f\x69\x87\x47\x65\x69\x06\xfd\xa6\xd9\xcb\x64\x3c\x22\xc7\x79\x4b\xed\xda\x3b\x51\x37\x68\x89\xe8\x50\x8f\x91\x86\x47\x12\x6c\x5b\xa8\xf9\xc4\xab\xba\x45\xbc\x89\x16\x8c\xe2\xfa\xfc\xa8\x2c\xd3\x0e\x64\x2c\x83\x10\x21\xd4\xca\x82\x39\xb4\x2b\xa9\x3c\x9e\xd0\xc3\x9e\xcf\xea\x6a\x7b\x6c\x31\x6e\x91\x62\x68\xdb\x95\x1b\xf0\x41\x14\xae\xa6\x7a\x40\x81\x33\xd2\x5f\x72\x05\x43\xe7\xe0\xeb\xbd\xd7\x61\xc4\xe5\x9a\xac\x41\x2a\x7c\x40\x4c\xed\xc6\xeb\xc3\x6b\x0e\x17\xa8\x63\x2c\x54\x29\x0c\x59\x86\x75\xe3\x26\x59\xc0\x74\xaa\x68\x4c\xeb\x92\xca\xb9\x19\x5c\x34\x2a\xe6\x48\x97\x4e\xaf\xe9\xb3\xb7\x52\xb8\x65\xb9\xb4\x1a\x42\xd4\x8d\x5b\x37\xa1\x1f\x7f\x42\x05\x3b\xb7\x8c\x11\x9a\xaf\xb1\xc3\x9a\x4a\x57\x96\xac\x93\xc1\x52\x1a\x2c\x28\x61\x74\x7a\x08\xc4\xab\x01\x58\x1e\x6f\x10\x6e\x1f\x8a\x38\xa9\x62\x79\x22\xa2\x47\x14\x47\x7b\xa4\xc2\xb7\x98\x06\x76\xec\xb6\xdd\x49\xe7\xfc\xf9\x41\x6a\x5f\x4e\x40\x92\x13\x2a\xed\xb9\x52\x2a\x2f\x7e\xbc\x95\x1a\x79\x87\x41\xea\x29\x6a\x68\xc5\x5c\xdb\xfc\xe7\x35\x2a\xc6\xf0\x7c\x90\xde\xe5\x2e\x1c\x14\xc8\x1a\xbb\xb8\x60\x14\x08\x99\xfb\xd0\xc5\x8b\xb5\xa7\x6e\x8f\x19\x6a\xd5\x62\x36\x07\xb2\x63\x14\x3a\x06\x5b\x60\x52\x0f\x64\xc2\xa6\x21\x7d\x21\xcd\xae\x41\xe8\xa8\x79\xa2\xd3\x4d\x42\xc5\xcc\xf4\xf8\xf6\x15\x0a\x07\xba\x2e\x4d\x3e\x30\x8c\xcf\xe8\xed\xbe\x1c\xe5\x59\x34\xe3\x91\x75\x25\x25\xcf\xb3\x78\x31\x34\x54\x33\x6a\x3f\xb6\xf7\xe5\xb9\x3b\x6c\x2e\x75\x1b\x80\x22\x78\xff\x81\x7a\x3e\xe3\xd1\x7d\x34\x51\x34\x0e\x47\xd4\x5c\x60\xa1\x0d\x31\x70\x32\xa6\xf2\xaa\x2c\x72\x52\x05\xba\x17\xdf\x09\xb2\xa4\x11\x27\xfd\x46\x91\xd7\x11\x7a\x6f\x1a\xc5\x2f\x18\x6d\xda\x5a\x71\x1f\x36\x6e\xd1\xf4\x3f\xd5\xf0\x22\xa4\xf3\x41\x6f\x72\xd8\xf5\x9a\xc1\xef\xee\x12\x6e\x63\xf5\x9a\x5f\x7c\x2d\x83\xe6\x57\x77\xdc\xb6\xf3\xf5\xc8\x97\xda\xf2\xa1\xd7\xcb\x8a\xfc\xd0\x6b\x6e\x66\x3d\x8e\xc9\xa4\xd9\xa6\xf6\x97\x76\x4f\xbd\xc3\xa6\xf6\x62\x41\x76\x9d\x26\x44\x86\x35\xbc\xa3\xba\x4e\xc3\xfa\xe8\x25\x5b\xd3\x32\x9b\x0f\xc4\x3f\x3c\x25\x16\x85\x10\x64\x06\xc0\x1b\xbb\xde\x2d\x95\x20\xd9\x7c\xcf\xe2\xdb\xf0\x9d\xde\x10\x82\x59\x17\x48\x04\x4f\xd3\x56\x24\xbf\xa1\x49\xc6\xfc\xaf\x14\x0d\x85\x37\x2c\xba\x6c\x53\x68\xf3\x7e\x57\xec\x7d\x32\x5e\x69\x76\xc6\x7e\x6f\x7f\xe7\xb6\xc3\x9f\xfb\x41\x82\x88\x76\x4b\x97\xc3\x22\x93\x06\x89\x9d\x30\xf0\x34\x02\x7b\x4e\xa3\xfb\xc9\x77\x10\xcc\x36\xa2\x59\x09\xa9\x48\x29\xc6\xa3\x38\x67\x44\x13\xa8\xf9\xa9\x72\x69\x42\x4f\x92\x0c\xbe\x7f\xf9\xfd\xcb\x0c\x12\x1a\xb9\xe0\xd1\xca\x61\xb9\xdb\x27\x6e\xd3\xe9\xf6\xe0\x20\x45\xa0\x22\xda\x62\x34\x3d\x9f\xd5\x0d\x65\xc6\x12\x18\xc5\xb2\xa3\xe9\x14\x84\x59\x34\x2b\x24\x37\xc0\xd1\x2c\xc1\xf7\xa1\x2e\xa0\xba\x93\x46\x2b\x7a\x0b\x0e\xeb\xda\x37\x33\x79\xd2\x93\xc7\x70\xc6\xa3\x58\x95\x88\xc7\x60\xc6\x27\xa1\x72\x96\x64\x9c\x71\xfb\x24\xf1\xe4\xed\xdb\x93\x4f\x21\x13\xa5\x09\xa0\xe4\x97\x08\x2b\x2c\x9e\x41\x57\x35\xcc\x60\x50\x2b\xcc\x80\x2a\x85\xe4\x3a\x39\x85\xcc\xfd\xbc\x9e\x91\x25\xda\x30\xc5\x93\xc3\x9b\x30\xa8\x43\xf0\x7a\x88\x3c\xab\xa4\xaa\x74\x4b\xe1\x0f\x54\x5f\x4e\xe8\x51\x92\x41\x25\x6a\x8b\xc4\x6a\x72\x85\xed\xf9\x81\xde\x52\x35\x53\x6a\xe5\x79\xa1\x00\x1f\xa4\xf3\xb0\xbc\xd3\x3c\xee\x4d\xc5\x0e\xe1\xd2\x8c\xea\x21\xcf\xad\xee\x85\xbf\x67\xa6\x75\x2f\x8a\xf3\x6e\xf6\x74\x88\xa1\x1b\x4a\xdd\x81\xff\xc5\x31\x5c\x1e\x9e\xdd\x45\xa8\x6f\xc8\xc6\xd5\x6e\xb9\x8b\xec\xa6\x8f\xe7\xd8\xcb\xd9\xd7\x0c\x70\x6e\xcf\x56\x65\xa0\xe3\x84\x25\xc9\x20\x1c\xba\xda\x1e\x52\x69\xbe\x47\x8c\xaf\xe4\xc2\x4b\x6a\xe0\x84\x9c\x88\x87\x3a\x3c\xe3\xb5\xc2\x4b\xca\xc5\xb6\x25\x8d\xdc\xf2\x2d\xd1\x98\xd0\x7f\x14\x2b\xb5\x04\x51\x78\x16\xa3\x22\x3f\x34\xcc\x1e\x2e\x78\x56\x1f\xa1\x75\x23\xf0\x87\x4f\x84\x64\xdb\xcc\xe2\x41\x9f\x1d\x3a\xe2\x79\xf6\xc8\xfc\xd5\xfb\xcb\x4f\xc7\x27\x17\x57\x5e\xea\x7b\x5d\x9a\xe7\xba\x59\xc4\xa9\xac\x6d\xed\xd1\x29\x42\xff\x72\x57\xd0\x23\xf4\x48\x1d\xcd\x34\xed\x23\xee\x16\x37\x4f\xd0\xf6\xd3\xc9\xdf\x3c\x69\xe7\xc6\x77\x0d\xa8\x51\x1e\x2f\x76\x6b\xcc\x61\x3f\xf6\x9f\x4e\xfe\xd6\xb2\x86\xdb\x25\xfb\xd9\xe3\x5f\x1e\x16\xe2\x29\x1e\xbd\x3f\x3d\x39\xbb\xfa\x74\x7c\xe4\xa9\x69\xbb\x33\x2d\x9b\xfc\x0c\xee\x86\xfb\x34\x7d\xb2\xa8\xc2\xa6\x48\xda\x6d\x6f\xda\xf6\x09\x4e\x45\x1c\x9d\xe4\xef\xbd\x4e\xaf\x13\xad\x90\xee\x21\xf8\xfc\xe2\xe7\x1f\xb6\xaf\x76\x87\xb0\xd8\x51\xa4\xf4\x77\x03\x61\xee\x1b\x1a\x45\xa3\x24\x1d\xa1\x19\xf0\x90\x61\x0c\xbe\xb9\xcd\xd8\x62\xdf\x3d\xc9\x10\x79\x77\x94\x7d\x77\xdf\x9d\x64\xbf\x04\x74\xb0\x9e\x91\x83\x5d\x9e\x93\x84\x84\x03\x3d\x47\x5e\x14\x8d\xe5\xab\xa2\x25\x2c\xd8\xb1\xe5\xab\xa2\x67\x58\xfc\xe0\x10\xbc\xbb\xba\x3a\x9f\xbe\x0a\x96\xa2\xa8\x51\x18\x5f\x0a\x2b\xb4\x52\x58\x50\xe0\x6d\x21\x5d\xbe\x2a\x26\x19\x49\x44\xd5\xd4\xb1\xdd\xb1\x36\xfa\x41\xa2\xf5\x6c\x20\xd5\xe6\xa1\xb6\x16\xe1\x1b\xee\x1e\xa7\x09\xbd\x3d\xa4\xd1\x1b\xdd\xb8\x24\x03\xf2\xb2\x1f\xc4\x83\x5c\x35\x2b\x28\x63\x8b\x99\xcc\x00\xad\x8b\xc9\x12\x2a\x27\xb9\x55\xec\xab\x0b\x2f\x61\x85\x42\x51\x15\x15\x18\x52\x8b\x38\xc4\x37\x5f\x40\x1f\x8a\x60\xbf\x95\x8a\x68\xba\xb8\x26\xf3\x24\x19\x3e\x31\x7c\x9a\x02\xff\xfa\x4b\xb8\x6f\xb0\xa2\x9f\x5a\x38\xe9\x7f\x25\xc4\xe1\x02\xf8\xad\x51\x1e\x62\x10\xf4\x24\x1d\xb2\xac\x9f\x21\x83\xde\x7e\x89\x0a\xa7\xe1\x5e\xc8\x58\x58\xa7\xf9\x92\x07\xd7\xb2\x41\x2b\x3f\x4a\x71\x28\x6a\x79\x87\x7d\x09\xf1\xc8\x57\xe2\x21\x5c\xc5\x0f\x1b\xea\x67\x46\xfc\x3e\xc6\x5a\x89\x87\x78\x05\x54\x13\xa2\x69\x41\x1f\xd7\xb3\x0c\x7f\x18\x6c\xed\xd1\x65\xe5\x7f\xb4\x25\xbf\xad\xcb\xf0\x38\xbd\xa4\xf9\x09\x5d\x8e\xd1\x0f\x28\x8a\xa0\x38\x6f\x44\xd9\x40\x9c\xdc\x88\x49\x4d\xa5\xbb\xb0\x3f\xc6\x9f\x07\x3b\xc1\xc5\xf6\xee\xde\xcf\x71\x9e\xda\xde\x0b\x1c\xb6\x77\xef\xe9\x6e\x75\xd1\x44\x06\x09\x39\xcd\xc3\xee\x09\x77\xbb\xb6\x90\xc4\x88\xaf\x2b\x3f\x75\x8f\x7c\xde\x73\xe9\x4f\x51\xa5\xc9\xec\x77\x65\x92\xc1\x41\x37\x2a\x4c\x4c\xe9\xc7\x1a\xb4\x59\x56\x90\x1e\x74\x66\xd8\x43\x9d\x50\x50\xcd\x4f\xc9\xa2\xf1\xc3\x38\xe7\x97\xbf\x15\x4e\xd4\x69\xf2\x83\xa6\x38\x62\x68\xa8\x41\xa8\xb2\xff\x8c\x4c\x98\xff\xe9\xc3\x8d\xff\xd1\xc6\x9d\x2c\xb1\xcc\x93\xde\x50\x20\xc7\xfc\x7e\x47\x1a\x4f\x92\xf9\x71\x36\xf2\x0b\xe1\x93\xff\x35\xc1\x41\xcf\xbd\x65\x7c\x10\x5e\x12\x29\xdd\x4d\x09\x7a\xf4\x52\x23\x95\xf1\x6e\xb1\x34\x86\x36\xdd\x94\x72\x47\x18\x57\x4e\xe2\x9a\xff\x1a\x86\x34\x1e\x67\xc0\xdf\x38\xff\xdc\x71\x77\x87\xb9\x43\xb6\x3e\xc5\xd0\x2e\x84\x89\x91\x4b\x6c\x2d\xc9\x6a\xc0\x36\x3e\xe1\x8b\x17\x2d\x7f\x7b\x62\xd4\x43\x7e\x38\x88\x16\xe2\x94\x8e\x7d\x82\x9e\x88\x2c\x8e\x2c\xb4\xf5\x10\x6e\xdf\xc7\x5b\xdd\x5b\xc7\xec\xb0\x86\xfb\x79\xdc\xae\x3b\x84\xc1\x52\xce\x96\x79\x98\x97\x8a\x35\xfc\xf1\xfd\xcf\x3f\x7e\x3a\xb9\xb8\xf8\xb5\xf7\xf5\xe3\xe5\xc9\x45\x06\x34\xed\x6a\x81\x26\x56\xe0\x84\x82\x3a\x22\x92\x2a\x49\x85\x7b\xa0\x11\x17\x1a\xca\x6b\xa7\x85\x3f\x9f\xe1\xfd\x2c\x96\x26\x24\x95\x16\x2a\x51\xe0\xe7\xc7\x38\xcd\xdb\x9d\x68\x58\xf3\xe0\x6b\x86\xe1\x74\xef\xe7\xb8\x83\x8a\x9e\x8f\x34\xba\xf9\xd0\xaf\x04\x7b\x57\xfb\xa1\x79\x20\x00\xab\xe6\x21\x0f\x13\xcc\x6f\x09\x79\x37\xc2\x9d\x71\x80\xcf\xe3\xcd\xbb\x4b\x93\x69\x92\xed\x0c\x41\xf3\xd9\xe2\xf0\x33\x99\x0f\xfe\xb5\x50\xfb\x73\x1a\x8f\xdd\xd0\xb9\x78\xd3\x2c\xf6\x02\xa8\x75\xd4\x3c\x50\x71\xe0\xa2\xf3\xe1\xf1\xed\x41\xcf\xaf\xc7\x25\x03\x6f\x3b\x83\x83\x1d\x0f\x4c\x0b\x7f\xe9\xf9\x43\x06\x76\xd0\xf7\x91\xb4\xe6\xb4\xac\xb7\x96\xc0\x41\xcf\x7f\xd1\x92\xa1\x5b\xf0\xab\x0e\x86\x5e\xa6\x2d\x5e\x1c\x50\xac\x43\x07\x0c\x47\xcf\xcf\xb9\xf1\x4d\xf6\x51\xe1\x7d\xea\xd9\xd0\x3e\x9c\xec\x59\x98\x5f\xa2\x7b\x77\x75\x75\xfe\x5d\x4a\xd5\x89\xe7\x56\xbc\x7a\x76\xc5\x47\x85\xca\xff\x52\x16\xcb\xc1\xe2\x9e\x62\x3e\xa5\x82\x0c\xce\xdf\x56\x1a\x15\x2b\x16\x1c\xdb\x49\x36\x96\xca\x27\x46\x7c\x77\x6c\xe8\xd7\x2a\x60\x44\x90\x0f\xc6\x3f\xfd\x66\xa6\x8b\x7e\x28\xc1\xb3\x54\x04\x6e\xde\xcd\x0e\xa6\x1d\x75\x7d\xcb\x33\x79\xfd\x15\x78\xf7\x1f\xff\xea\xfd\x65\xcb\x01\xce\x32\x93\xc9\x64\xfc\x38\xfe\xcf\x01\x00\xda\xae\xc3\xf6\xb2\x3d\x00\x00")
 
 func effeEffeGoBytes() ([]byte, error) {
 	return bindataRead(
@@ -84,27 +94,227 @@ func effeEffeGo() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "effe/effe.go", size: 1200, mode: os.FileMode(436), modTime: time.Unix(1461601460, 0)}
+	info := bindataFileInfo{name: "effe/effe.go", size: 15794, mode: os.FileMode(436), modTime: time.Unix(1792408349, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _effeLogicLogicGo = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\x90\xcb\xae\xd3\x30\x10\x86\xd7\xf8\x29\x46\x96\x90\x6c\x88\x52\x0a\x88\x45\x25\x56\x15\x97\x6e\x2a\xd4\x82\xd8\x20\x51\x2b\x99\x34\x16\xc9\x38\x38\x93\xa6\x47\x55\xdf\xfd\x8c\xd3\xcb\xe9\x26\xb1\xc7\xff\xf8\xfb\x3c\x9d\x2b\xfe\xb9\x3d\x42\x13\xf6\xbe\x50\xca\xb7\x5d\x88\x0c\x46\xbd\xd2\x55\xcb\x5a\x7e\xad\xe3\x7a\x16\x1d\x95\x69\x43\xc8\xb3\x9a\xb9\x4b\x6b\xf6\x2d\x6a\x65\x95\x3a\xb8\x08\x2b\xaa\x02\xf4\x1c\x3d\xed\xe1\x33\xec\xd4\x29\x85\x9d\x04\x16\xa0\x6b\x6c\x9a\xf0\x17\xab\x0a\x75\x26\xe5\x03\xc6\xde\x07\x4a\x27\xef\xf2\xf9\x54\x2a\x43\xa1\x41\xf6\xdf\x90\x39\xdd\xd0\xb3\x13\x89\xd1\x73\x0d\x53\x9b\x3a\xab\x9d\x52\xfc\xd4\x21\x2c\x03\x31\x1e\x39\xb1\x86\x82\x41\x38\x07\xd7\x0c\x08\x9e\xf8\xd3\x47\xc9\xa9\x6a\xa0\x42\x74\x3c\x1b\x9b\x4e\x93\x79\xbe\x45\x2c\x4d\xf2\xcd\xd7\x61\x34\x36\xff\xf5\x73\x99\xbe\xe4\x8f\x6b\x47\xc1\x58\x7b\x6f\xdc\x26\xb2\x74\x9a\x2b\x27\x03\x8c\x31\xc4\xe9\x2a\x19\x48\xfe\x43\x5e\xc8\x0d\x19\x3d\x05\x81\x70\xbc\x19\x69\x2b\x30\xe4\x21\xd2\xad\x72\x9a\xc3\x5b\x98\xf8\x2b\x91\xfb\x40\xe6\xbd\x3d\x67\x40\xbe\xb9\xd3\x36\x03\x99\x82\x8f\xf0\x08\xbb\x00\x33\x18\x21\x0d\x3a\xdf\x60\xdf\x05\xea\xf1\x77\xf4\x8c\x52\x8e\xf0\xe6\x5a\xff\x3f\x60\xcf\xf6\x12\xbf\xe9\x7d\xed\x92\x5f\x65\xc6\x0c\xf4\xf7\x34\x76\xa8\x62\x68\xe1\x8b\x0c\x71\x01\xf0\xba\xfc\x43\x3a\x03\x21\xe6\xd3\xd0\x5e\x8c\x1f\xa5\xb6\x1c\xba\x47\x2b\x79\x3b\x5c\x63\x67\xf5\x1c\x00\x00\xff\xff\xad\x64\x5c\x87\x2f\x02\x00\x00")
+var _assetsDockerAlpineDockerfileTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x8e\xcd\x0e\xda\x30\x10\x84\xef\x7e\x8a\x15\xe5\x88\x53\x54\xd4\x0b\x52\x0e\x90\xba\x02\x35\x24\x51\xf8\x51\x39\x9a\x64\x09\x2e\xc1\x4e\x6d\x87\x82\xac\x7d\xf7\x2a\xa5\xe2\xd6\xde\x76\x76\xe6\x1b\xcd\x07\x08\x21\xca\xe4\x0d\x89\x86\xeb\x80\xd6\x29\xa3\x89\x26\x20\xdb\x4e\x69\x04\x75\x93\x0d\xc2\x2f\xe5\x2f\x20\xc1\x5d\xb0\x6d\x27\x70\x36\x16\x6a\x3c\xf5\x4d\xa3\x74\x13\xb1\xaf\x65\xbe\x81\x10\x8c\x85\x68\x29\x1d\xae\xff\x10\xa3\x17\x3f\x9f\x45\x9f\xa6\x23\x22\x56\xee\x33\x90\xdd\x15\x64\x5d\x03\xe7\xda\xf0\x4a\x56\x17\x84\x4a\xf2\x0a\xad\x57\x67\x55\x49\x8f\x8e\x25\x79\x71\x1c\x86\x88\x07\x56\x44\xf0\x11\x1f\x58\xb1\x10\xac\xd4\x0d\xc2\xf8\x8a\xcf\x09\x8c\xef\xb2\xed\x11\xe6\x31\x44\x42\xdf\x89\x44\x76\x80\x10\x06\x8f\x28\x0e\xe1\x67\x6f\x3c\xfe\x0d\x11\xb1\x10\x50\xd7\x44\xff\xac\x48\xe5\x09\x5b\x47\x94\x2e\x96\x22\x85\x37\xfe\xff\x36\xf1\xbd\xc8\xb7\x62\xd8\x59\x18\xeb\x89\xd8\x7e\x2b\xca\x41\xee\x1d\x5a\x22\xb6\x12\x8b\x74\xb7\x4a\x56\x22\xf9\x06\x9c\x2b\xed\xd1\xde\x65\x1b\xcf\xa6\x0e\x38\xf7\xea\x86\xa6\xf7\xf1\xe7\x41\x38\x2f\xad\xe7\x1d\x5a\x65\xea\xd7\xc7\xa2\xb7\x0a\x5d\x3c\x83\x64\xf3\x05\x42\xf8\xe1\x8c\x86\xa8\xb0\xe6\x84\x44\x4c\x64\xbb\xf2\x58\xe4\xeb\x6c\xf7\xb6\x84\xf6\xf6\xd9\x19\xa5\x3d\x11\xfb\x3d\x00\x1b\x88\x75\xab\xd0\x01\x00\x00")
 
-func effeLogicLogicGoBytes() ([]byte, error) {
+func assetsDockerAlpineDockerfileTmplBytes() ([]byte, error) {
 	return bindataRead(
-		_effeLogicLogicGo,
-		"effe/logic/logic.go",
+		_assetsDockerAlpineDockerfileTmpl,
+		"assets/docker/alpine.Dockerfile.tmpl",
 	)
 }
 
-func effeLogicLogicGo() (*asset, error) {
-	bytes, err := effeLogicLogicGoBytes()
+func assetsDockerAlpineDockerfileTmpl() (*asset, error) {
+	bytes, err := assetsDockerAlpineDockerfileTmplBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "effe/logic/logic.go", size: 559, mode: os.FileMode(436), modTime: time.Unix(1461600381, 0)}
+	info := bindataFileInfo{name: "assets/docker/alpine.Dockerfile.tmpl", size: 464, mode: os.FileMode(436), modTime: time.Unix(1792406753, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _assetsDockerDistrolessDockerfileTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x8e\xcd\xae\xd3\x40\x0c\x85\xf7\xf3\x14\xd6\xe5\x2e\xf3\x03\x5c\xb1\xa9\x94\x45\x1b\x06\xb5\x22\x4d\xa2\xfe\x89\x2e\xa7\x89\x29\x03\xe9\x4c\x19\xbb\xa5\x65\xe4\x77\x47\x11\xa8\xac\x60\xe7\xe3\x63\x7f\xe7\xbc\x82\x18\xb3\xda\x9c\x50\x64\x9c\x76\x18\xc8\x7a\x27\x92\x40\x6f\x89\x83\x1f\x90\x08\xec\xc9\x1c\x71\x02\xe5\x14\x3a\x0c\x6c\x3f\xdb\xce\x30\x52\x02\xfc\xb3\x37\x6c\xc0\xb8\x1e\x72\xe4\x2e\x3f\x1b\xa2\x1f\x7d\x02\xce\x03\x7d\xc1\x61\xc8\xd4\x87\x55\xb3\x84\x18\x7d\x80\x6c\x66\x08\x17\x23\x08\x9e\x8e\x5d\xc8\xac\xcf\xff\x26\xe4\xc4\x86\x6d\x97\xf6\x78\xb0\xc6\xbd\x79\xfb\x24\xa2\xca\xa6\xdd\x8f\x95\xf4\x0d\x3b\x11\xc8\xf1\x86\x9d\x8a\x31\x18\x77\x44\x78\xfe\x86\xf7\x04\x9e\xaf\x66\xb8\x20\x4c\x0a\xc8\xb4\xbb\x8a\xe8\x7a\x07\x31\x8e\x9e\x48\x11\xe3\xf7\x8b\x67\xfc\x73\x24\xa2\x62\x44\xd7\x8b\xfc\x13\x51\x99\x03\x0e\x24\x52\x4d\x67\xba\x82\xc7\xfb\xff\x69\xfa\x53\xdb\xac\xf5\xd8\xb3\xf5\x81\x45\xd4\x76\xad\x57\xa3\xdc\x12\x06\x11\x35\xd7\xd3\x6a\x33\x2f\xe7\xba\xfc\x08\x69\x6a\x1d\x63\xb8\x9a\xa1\x78\x79\x4d\x90\xa6\x6c\x4f\xe8\x2f\x5c\xbc\x1b\x05\xb1\x09\x9c\x9e\x31\x58\xdf\xff\xde\x04\xe4\x60\x91\x8a\x17\x28\x97\xef\x21\xc6\xaf\xe4\x1d\x64\x6d\xf0\x07\x14\x51\xba\xde\xac\xf6\x6d\xb3\xa8\x37\x0f\x4b\x3b\x0e\xf7\xb3\xb7\x8e\x45\xd4\xaf\x01\x00\xfd\xcf\x87\x11\xda\x01\x00\x00")
+
+func assetsDockerDistrolessDockerfileTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsDockerDistrolessDockerfileTmpl,
+		"assets/docker/distroless.Dockerfile.tmpl",
+	)
+}
+
+func assetsDockerDistrolessDockerfileTmpl() (*asset, error) {
+	bytes, err := assetsDockerDistrolessDockerfileTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/docker/distroless.Dockerfile.tmpl", size: 474, mode: os.FileMode(436), modTime: time.Unix(1792406753, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _assetsDockerScratchDockerfileTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x8e\xc1\x6e\x1a\x31\x10\x86\xef\x7e\x8a\x51\x92\x23\xbb\x5b\x29\xea\xa5\xd2\x1e\xc8\xd6\x15\x51\x09\xac\x08\x89\x9a\xa3\x31\x43\x70\xbb\xd8\x74\x3c\x50\xd0\x68\xde\xbd\xda\x6d\x44\xdb\x43\x7b\x9b\xff\x1b\xcf\xef\xef\x1a\x44\xca\x99\xdb\xa1\x6a\x3f\x3d\x23\xe5\x90\xa2\xea\x08\x5c\x04\xdc\xed\xf9\x0c\x61\xe7\x5e\x11\x7e\x04\xde\x42\x8a\xdd\x19\x78\x8b\xd0\x8c\xc1\x23\x71\xd8\x04\xef\x18\x73\x69\xae\x07\xfc\x27\x03\x47\x38\xc0\x14\x31\x43\xda\x0c\xf3\x36\x65\x1e\x81\x4f\xfb\x80\x6b\x08\x71\x60\xab\x43\xe8\xd6\xe0\x53\x64\x3c\xb1\xf9\xb4\x98\x3f\x80\x48\x22\x28\xef\x5c\xc6\xfb\xe1\xf3\xab\xec\xc9\xb1\xdf\x5e\xa9\x1a\x91\xb0\x81\xb2\x41\xe2\xac\xda\xcc\xdb\x97\xde\xfb\x2d\x42\x85\xec\xab\x9c\xbb\xaa\x37\xc9\x95\x77\xc5\x5f\x9a\x9e\xd8\x88\x60\x5c\xff\xbe\xb4\x27\xf4\xaa\x50\xe1\x09\xbd\x11\x21\x17\x5f\x11\x6e\xbe\xe1\x79\x04\x37\x47\xd7\x1d\x10\x3e\xd4\x50\xda\x78\x54\xb5\xb3\x67\x10\xe9\x77\xaa\xb5\xc8\xf7\x43\x62\x7c\x7b\x34\x78\x0d\xbd\xff\xac\x98\xba\x15\x76\x59\x75\x3a\xbe\xb3\x53\xb8\x9c\xff\xbf\xcd\x7e\x69\xe7\x8f\xb6\xf7\x6c\x13\xb1\xaa\x79\x7a\xb4\x8b\x3e\x3e\x65\x24\x55\x33\xb1\xe3\xe9\x72\xd2\x4c\x6c\xf3\x19\x8a\x22\x44\x46\x3a\xba\xae\xbe\x7d\x97\xa1\x28\x38\xec\x30\x1d\xb8\x7e\xdf\x87\xcc\x8e\xb8\xd8\x23\x85\xb4\xfe\x45\x08\x99\x02\xe6\xfa\x16\x9a\x87\x8f\x20\xf2\x35\xa7\x08\x65\x4b\x69\x85\xaa\xc6\xce\x96\x8b\x97\x76\x7e\x3f\x5b\x5e\x56\x36\x32\x9d\xf7\x29\x44\x56\x35\x3f\x07\x00\xce\x0f\x5a\xfb\x39\x02\x00\x00")
+
+func assetsDockerScratchDockerfileTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsDockerScratchDockerfileTmpl,
+		"assets/docker/scratch.Dockerfile.tmpl",
+	)
+}
+
+func assetsDockerScratchDockerfileTmpl() (*asset, error) {
+	bytes, err := assetsDockerScratchDockerfileTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/docker/scratch.Dockerfile.tmpl", size: 569, mode: os.FileMode(436), modTime: time.Unix(1792406753, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _assetsHarnessGoTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8c\x56\x5f\x6f\xdb\x46\x0c\x7f\x96\x3e\x05\xab\x87\x41\x5a\xb5\x73\x93\x15\xc5\x96\x42\x0f\xfd\x93\xb5\x19\xb6\xac\x88\x83\xed\xa1\x2d\xda\xb3\x45\xdb\xb7\xca\x77\x0a\x8f\xb2\x6b\x14\xfe\xee\x03\xef\x24\xd9\x4e\xf3\x30\x20\x81\xef\x78\x24\x8f\xfc\x91\xfc\x9d\x5a\x3d\xff\xa2\x97\x08\x6b\x6d\x6c\x9a\x4e\x26\x70\xbb\x32\x1e\x8c\x07\x5e\x45\x21\x74\x1e\x6b\x98\xed\xe0\x33\x2e\x16\xf8\x13\x3b\xd7\x00\xa3\xe7\xcf\x25\x18\xeb\x19\x75\x0d\x6e\x01\x1e\x69\x63\xec\x52\xac\xc4\x89\xa8\x82\x61\xa0\xce\x7a\xc0\x0d\xd2\x0e\x08\xef\x3a\xf4\x0c\x24\x16\x0b\x72\x6b\xd1\x05\xcf\xda\xd6\x9a\x6a\x30\xb6\xed\x18\x78\x45\xae\x5b\xae\xc4\xc5\x95\x35\x5c\xc2\x94\x35\x71\x09\x37\x9d\x05\x6d\x6b\x98\xb2\x6b\xc3\x62\x4b\x86\x31\x06\x49\xe8\x5b\x67\x3d\xfa\x12\xb4\x07\x0d\xbf\x4f\xff\xba\x16\x07\x9a\x48\xef\x4a\x70\xf6\xf4\x22\xd7\x71\xdb\xb1\x4a\x53\xb3\x6e\x1d\x31\xe4\x69\x92\xcd\xba\x85\x71\x99\x2c\x76\x8c\x5e\x16\x68\xe7\xae\x36\x76\x39\x99\x69\x8f\xcf\x9e\x9e\x88\xfe\xf5\xce\x8a\x60\xb1\x66\xf9\x59\x1a\x5e\x75\x33\x35\x77\xeb\x89\x37\x7e\x6e\xf4\x44\xb2\x9f\x34\x6e\x69\xe6\x72\x6e\xdc\xc4\xb8\x8e\x4d\x23\x1b\x8b\x3c\x59\x31\xb7\xc7\xeb\x20\x10\x48\x45\xe8\xc2\xf5\x9e\xc9\xd8\x65\x58\xb2\x59\xa3\xfc\x76\xd6\xcc\x5d\x8d\x93\x8e\x17\xbf\x64\x69\x11\x6a\x25\x89\xa1\xdd\x60\xe3\x5a\xf4\xa0\x09\xa1\xc6\x79\xa3\x29\x16\x6c\xac\x57\x79\xc0\xdb\x59\xf4\x52\x98\xce\xa3\x4f\xbf\x7d\x53\x37\xb1\x2a\xfb\x7d\x1a\x77\x11\x4a\xd9\x2e\x3a\x3b\x87\x9c\xee\x86\xc2\x15\x20\x61\xf6\xfa\x79\x01\xf9\x8f\xb2\x1f\x1c\x94\x80\x44\x8e\x0a\xf8\x96\x26\x33\x57\xef\xe0\xa2\x82\xf7\x1f\x67\x3b\xc6\x9c\xee\xd4\x4b\x57\xef\x8a\x34\x31\x0b\xa0\x3b\x75\xe5\x5f\x06\x4c\x2f\x05\x62\xac\xc5\x22\xd9\x68\x12\x07\xf2\xef\x28\x4d\x44\x53\xbc\x04\xaf\x50\x41\x2c\x82\x9a\x72\x7d\xd9\x17\x41\xbd\x46\xb1\x9e\x06\x9c\xc6\x2b\x9e\x07\xfd\x47\x15\x58\xd3\x04\xbf\x09\x21\x77\x64\x65\x1f\x7c\xa5\x49\xb2\x4f\xe5\x2f\x86\xf2\x27\xf2\xca\xd5\x50\x55\x90\x65\x41\xff\x48\x06\xd9\x9b\xcb\xdb\xec\x48\xf9\x9d\xe6\xd5\xa9\x6a\x94\x40\x36\x89\x6a\x93\x09\xb4\x9a\x64\x5a\xb4\x87\xa1\xa6\xea\x1a\xb7\x3d\x46\x50\x3b\x69\xd2\xad\xe1\x95\xeb\x18\x0c\x7b\x68\xb5\x35\x73\x69\x51\x6d\xc1\xd8\x8d\x6e\x4c\x3d\xe0\x9d\x26\x14\x42\x16\x24\x7b\xa4\x75\xdd\x7b\xca\x43\xc3\x46\xd7\xba\x46\xca\xfb\x7e\x39\x92\x1c\x32\x79\x0c\x19\x64\xf0\x78\x4c\x41\xf6\x6f\x6f\x6f\xdf\x4d\xce\xd4\xd9\x07\xfa\x60\xdf\x3a\xcf\x17\x80\x5f\xf5\xba\x6d\x50\xda\x58\x84\xf2\x9f\x15\x45\x2c\xda\x3d\x50\xbf\xc3\x74\x9f\x26\x14\x6a\x0c\x15\xc4\x56\x57\xd7\xae\x7d\xd5\x38\x8f\x94\x4b\x0f\x1c\x07\x26\x75\x15\xbf\xa4\x5e\x39\xcb\x68\xf9\x0f\xb4\xcb\x80\xa3\xb1\xfc\xec\x69\xde\xa0\x3d\xd2\xb9\xc1\xb5\x63\x7c\x51\xd7\x24\x40\x9f\xfd\x7a\xae\x9e\xa8\x73\x75\x76\x71\x76\xfe\xb3\x0c\xe5\xc2\x11\x7c\xc1\x5d\x09\x1b\xdd\x74\xe8\x05\x2b\xd2\x76\x89\x92\xec\xdb\x70\x9f\x0f\x21\x8b\xde\xa7\x5e\xeb\xa0\xd4\x1b\x89\x42\x42\xbd\xbe\x7a\x51\xd7\xf9\xc1\x65\x71\xdc\x31\x2b\xe7\x39\x58\x0f\xba\x6f\x90\xf3\x4c\xf0\xcb\x8a\xe7\xf1\xf4\xd1\xa1\x3f\x94\x1c\x40\x15\xe4\xc1\x43\x8f\x1b\x95\x02\x5d\x3a\x8c\x18\x75\xf6\x64\xca\x06\x3a\x13\x27\x32\x16\x84\x7e\xa4\xb8\xe3\x9e\xa0\x3b\x75\x32\x90\x0f\x57\xca\x0f\x03\x7a\x29\x93\x05\x95\x68\xa8\xb0\xce\x8b\x43\x29\x09\x7d\x88\x70\x3b\x34\xdb\xa1\x73\xe7\x8e\xa4\xc1\x8a\x34\xf1\x42\xc5\xa2\x20\x8c\xa4\xae\xdd\x56\x3c\xcc\xf9\x6b\x29\xdc\x4a\x7c\x19\xc3\x0a\xb4\xa7\x02\x6d\xf7\x31\x8d\xa7\xf7\x02\x9b\xf6\xf2\x10\xd6\xa0\x74\x88\x6d\x9f\x26\x42\x41\x79\xa0\x94\xa4\xc6\x05\x12\x1c\x09\x24\xdb\x56\x2e\x24\x9c\xbb\x8d\x44\xf8\x1c\xda\xe3\xdc\x93\x64\xab\xfe\x91\x57\x22\xd6\x2a\x97\xb4\x24\x2e\xee\xfc\x95\x65\x24\xab\x9b\x29\xd2\x06\x29\xdc\x58\x88\xcb\x10\xd6\xbb\x30\x92\x15\x2c\xd6\xac\xa6\x2d\x19\xcb\x79\x1b\x4e\xf7\xd2\x0a\x12\xd9\x00\xf4\x98\xed\x4d\x67\xf3\x13\x20\x4a\xd8\x96\x40\x0f\xd2\x91\x57\x37\x9d\x7d\xb8\x18\xc2\x4c\xf7\x31\xab\x0e\xc6\x03\xb2\xae\x95\xcb\x8a\xbe\xa5\xbc\x7a\xdd\x91\x66\xe3\x2c\xf4\x95\x99\x1a\x3b\xc7\x3c\x00\x5a\xa4\x41\xa5\x6b\x42\xdb\x6e\xd5\x4d\x58\xcb\x1d\x32\x61\x25\x7c\x82\x8b\x71\x62\x65\x3c\x5f\x34\x4d\x1e\xf5\x07\xca\xee\x0b\xc5\x9d\x07\x81\x3a\x1c\xc5\xfd\x2b\x57\x63\x3c\x1f\x06\x6d\x54\x88\x82\x78\xd8\xd3\x42\x24\xa8\x38\xd8\x21\xc3\x47\xf2\x8e\xa9\xbf\x85\xf1\xa2\x74\xec\x8b\xde\xe2\x01\xce\x0f\x0b\xbc\x75\xd3\x13\x67\xc1\xe8\xfe\xa3\x52\x01\x53\x87\x27\x63\x87\x7e\x9c\x39\xf9\xae\x89\x7d\x14\x27\x2c\x8c\x90\x87\xf7\x1f\xfb\xe5\x38\x4b\x17\x15\xc8\x6b\x2f\xa3\x10\x1f\x1c\xca\x9d\x00\x52\x1b\x5b\xf4\x4f\x50\xfe\xc3\x60\xff\x7d\xbd\xa5\x87\x7e\x0b\x3d\xd4\xd8\xde\x10\x89\x4a\xc8\xae\xd6\xad\xf3\xde\xcc\x1a\x04\x76\xf1\x93\x48\x5e\xe7\xc1\xd3\x05\x64\x8f\x8f\x7a\x43\x9a\xc3\x79\x75\xf9\xd5\x70\x7e\x1e\x0a\x2f\xd3\x58\xcb\x23\x72\x51\x41\xf4\xeb\x3a\x4e\x93\x71\x09\x83\x58\xf8\x39\xed\x5b\x47\xbe\xa8\xa4\xf6\x03\x9b\x04\xba\x5c\xeb\x2f\x98\x4b\xe2\x51\x56\xc2\x93\x12\x84\x82\xc7\xa4\x8a\xc8\xb2\x9f\x4a\xa0\xbb\x23\x7e\xed\x8f\x87\xaa\xf5\x0e\x2b\xd0\x6d\x8b\xb6\xce\x47\x51\xd9\x13\x9c\xd0\xf9\xfe\x21\x5c\x63\xc5\xe4\x09\x93\x84\x0a\x15\xf7\x07\x07\xff\x1f\x55\x24\xfa\x1e\xa9\x7d\xfa\xdf\x00\x47\x98\x65\x23\xe2\x0a\x00\x00")
+
+func assetsHarnessGoTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsHarnessGoTmpl,
+		"assets/harness.go.tmpl",
+	)
+}
+
+func assetsHarnessGoTmpl() (*asset, error) {
+	bytes, err := assetsHarnessGoTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/harness.go.tmpl", size: 2786, mode: os.FileMode(436), modTime: time.Unix(1792407947, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _assetsTemplatesFormGoTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x54\x5f\x6f\xdb\xb6\x17\x7d\x16\x3f\xc5\x0d\x81\x14\xd2\x0f\xaa\xfc\xfa\x43\x6a\x19\xeb\x5a\x37\xcb\xc3\xda\xa0\xf6\x36\x0c\xe8\x43\x69\xe9\x2a\xe6\x42\x91\x1a\x79\x65\x37\x70\xf5\xdd\x87\x4b\x59\x4e\xe2\xf4\x61\xd8\x83\x61\x89\xf7\xdf\xb9\xe7\x1c\xb1\x53\xd5\xbd\xba\x43\x30\xee\x4e\x57\x42\xcc\x66\xf0\x16\x1a\xe7\x5b\xd8\x2a\x5b\x1b\xf4\x57\x70\xbd\x5c\x43\xd8\xba\x7d\x00\xda\x62\x8c\xe5\x70\xfb\x69\xb5\x86\x9d\x32\xba\x56\x84\x63\x20\xf4\x9b\x56\x13\x61\xcd\x3d\x76\xca\xf4\x18\x40\xd9\xfa\xb1\xb4\x85\x8d\xaa\xee\x0b\x21\x74\xdb\x39\x4f\x90\x8a\x44\x36\x2d\x49\x91\xc8\x2d\xb5\x86\xff\x2d\xd2\x6c\x4b\xd4\xf1\x73\x20\xaf\xed\x5d\x90\x22\x13\x62\xa7\x3c\xdc\xd8\xc6\xc1\x78\x08\x25\x7c\x15\x07\xce\x57\x2d\xca\x2b\x38\x1c\xfe\x0a\xce\x42\xf1\x51\xb5\x38\x0c\xb9\x48\xe4\x0e\x7d\xd0\xce\x3e\x89\xfd\x3e\x9e\x8c\xe1\xda\x55\x12\x1e\x63\xef\x5d\x35\x0c\x62\x10\x5f\x85\xa8\x9c\x0d\x14\x97\xe4\x21\xf3\xf8\xd0\x22\x6d\x5d\x5d\x4a\x5e\x5a\x2e\x44\x32\xd7\xb6\xeb\x09\xac\x6a\xb1\x1c\x21\x40\x67\x54\x85\x5b\x67\x6a\xf4\xa5\x64\x18\x92\xd9\xe9\xb1\x94\x97\xe1\x45\x09\xb6\x4a\x9b\xb3\x9a\xe5\x78\xf6\xbc\x68\xd3\x13\x39\x0b\xf4\xd0\x61\x29\x47\x7e\xe5\x62\x85\xb6\x9e\xcf\xc6\xd0\x42\xcc\x67\x0c\x71\xc1\xd0\x39\x0d\xde\x39\x4b\xf8\x8d\x98\xa8\xbe\xa2\xc3\x20\x44\xd3\xdb\x0a\x6e\xac\xa6\x34\x83\xd3\xfb\x8a\x94\xe7\x83\xf4\x98\x9f\x03\x7a\xef\x7c\x06\x07\x91\x78\xa4\xde\xdb\xa9\xd3\x61\xc8\xc1\x6a\x23\xa6\xca\xcf\xbd\x4d\x2b\xfa\x36\x85\x63\x21\xff\x9c\xcf\x61\x0f\x2c\x5e\xf1\x19\x43\xe7\x6c\xc0\x3f\xbc\x26\xf4\x39\x78\xf8\xdf\xf1\xfc\xef\x1e\x03\x65\x63\x3a\x8f\xd2\x0d\x3f\xc3\x45\xc9\x23\xf8\x20\x89\x89\x4b\x8e\xa7\xfb\x1c\xe4\x0a\xfd\x4e\x57\x08\xbd\x55\x3b\xa5\x8d\xda\x18\x94\xf9\x38\x65\x45\x8a\xfa\x70\x63\x09\xbd\x55\x86\x13\xd1\xc7\xc2\x4c\x24\xd3\x0e\xe8\xbd\x48\x06\x91\xec\x8b\x5f\x50\xd5\xe8\xd3\xac\x58\x21\xa5\x32\x82\xb7\xf4\x7a\xfd\xd0\x71\x3f\xc9\x9b\xcc\xd8\x84\x6f\xa0\xda\x2a\x1f\x90\xca\x9e\x9a\xd7\xff\x97\x99\x48\xd8\x7b\x2c\x72\x0e\x51\xb7\x1c\x5a\x0c\x81\xbf\x98\xd1\x8b\x22\x09\x7b\x4d\xd5\x16\x7c\xf1\x6b\xf4\x09\xaf\x51\xa9\x80\x20\xaf\x97\x6b\x79\x35\xbd\x44\xf3\x5c\x89\x64\xda\xf9\xaa\x04\x5f\xdc\xf2\xac\x0f\xce\xb7\x69\xf6\xe6\x9c\x89\x73\x2a\x6e\x6c\xfc\xde\xa2\x37\x9f\x73\xf0\xb3\xaa\x27\x6a\x45\x72\xda\x9d\x45\x4b\x78\xf9\x84\xd1\x43\x79\xc4\x1b\x8a\xb5\xd7\xed\xaa\x53\x15\xa6\xbe\xb8\x75\x81\x78\x7e\x71\xcd\xb4\x70\xa2\xcc\x98\xbf\xb8\xea\xbf\xaa\x89\x99\x63\x91\x6e\xa2\xc1\xa1\x2c\x41\x4a\xf8\xfe\x1d\x2e\xa6\x72\xe6\x5b\x69\x1b\xd2\x23\x85\xf2\x27\x19\xad\x96\x24\x13\x99\x25\xc8\x5b\x83\x4c\x5b\xe7\xdd\x4e\xd7\x08\x6a\x6c\xc6\x57\x88\xe2\x8f\x49\xd7\xa3\x00\x85\xe4\x25\xf7\x45\x74\xd7\x51\xd6\x27\x64\xfc\x66\x3b\xef\x2a\xee\xba\x31\xb8\xb4\xa4\xe9\x81\xb1\x0d\x22\xa9\xb1\x51\xbd\x21\xd6\xe0\xdc\x0f\x6f\x8d\x71\x7b\x36\xc2\xf5\x72\x3d\x5e\x6e\x32\x7b\x69\xc6\xa3\xbe\xd6\x11\x28\x2e\xc0\xfa\xb9\x0e\x63\xfc\xa3\xa3\xd8\x0e\xeb\x27\x46\x8c\x62\x0c\x22\x69\x5a\x2a\x3e\x74\x5e\x5b\x8a\xf6\x9e\x5f\xbc\xff\xf4\x6e\xfd\xe7\xed\x12\xd8\x7c\x8b\x2f\x76\x3e\xfd\x6f\x5c\xfd\xb0\xf8\x62\x19\x86\x6e\x1e\xbd\xc5\xd4\x46\x78\xf0\xea\xd5\xc9\x88\x7c\x28\x23\x9d\x8f\xed\x9b\xb1\x7f\xb7\x58\x6f\x95\xbd\x87\x07\xd7\xc3\x65\xc8\x61\x8f\xb0\xd7\xc6\xc0\x9e\xc9\x03\x72\x70\x19\x8a\xf9\xac\xe3\x49\xbc\x4a\x6b\x8a\x65\xa8\x54\x87\xab\x28\x5c\xca\x0a\x64\x3f\x0a\x44\x25\x58\xf4\x01\xd0\x04\x8c\xc3\x75\x73\x42\x74\x71\x42\xf4\x23\x48\x97\xe1\x34\xf2\x58\x70\x54\xe8\x3c\x99\xad\xfe\x1f\x61\xbd\xa4\x7a\x76\xa4\x74\x3e\x3b\x72\xcc\xdc\x3e\x91\xe7\xf1\x6a\x74\xdd\xd3\x1b\x2e\x83\x03\x78\xa4\xde\x5b\x18\xc4\x3f\x03\x00\x16\x64\xb3\x07\x2e\x07\x00\x00")
+
+func assetsTemplatesFormGoTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsTemplatesFormGoTmpl,
+		"assets/templates/form.go.tmpl",
+	)
+}
+
+func assetsTemplatesFormGoTmpl() (*asset, error) {
+	bytes, err := assetsTemplatesFormGoTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/templates/form.go.tmpl", size: 1838, mode: os.FileMode(436), modTime: time.Unix(1792402412, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _assetsTemplatesHelloGoTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\x90\xcb\x6e\xdb\x4c\x0c\x85\xd7\xe2\x53\xf0\x1f\xe0\x07\x66\x5a\x41\x41\x2f\xc8\x22\x40\x56\x69\x8b\x7a\x63\x14\x71\x2f\x9b\x2e\x32\x90\x39\xce\xb4\x12\x47\xa5\x28\xdb\x85\x30\xef\x5e\x8c\x1c\x27\x5e\x48\xa0\xce\x21\x75\x3e\x72\xf0\xed\x6f\xbf\x23\xec\xd2\x2e\xb6\x00\xb1\x1f\x92\x28\x5a\xa8\x4c\xe8\xd5\x40\x65\x7a\xaf\x8f\x57\xe2\x79\x5b\x3e\x98\xf4\xea\x51\x75\x28\xb5\xc6\x9e\x0c\x38\x80\xbd\x17\x5c\x71\x48\x38\xaa\x44\xde\xe1\x2d\x3e\xc0\x5c\x9a\x7d\x4f\xe6\x06\xe7\xf9\xd7\x98\x18\x9b\xb5\xef\x29\xe7\x1a\x2a\xb3\x27\x19\x63\xe2\x0b\xef\xfb\x49\x39\xd9\xdb\xd4\x1a\x7c\xf1\x3e\xa4\x36\x67\xc8\xf0\x00\xa0\x7f\x07\xc2\xbb\xc4\x4a\x47\x2d\x69\x53\xab\x38\x43\xb5\xf7\xdd\x44\x18\x59\xaf\xdf\x43\x06\x08\x13\xb7\xb8\xe2\xa8\xd6\x15\xb7\xb0\x37\x1b\xa2\xad\x2d\xc4\xcd\x3a\x1d\xac\x6b\xbe\x7d\xbd\x2b\x6f\x8e\xc7\xb5\xe7\x64\x9d\x7b\x1e\xdc\xa8\x97\x32\x69\x9f\x72\x6a\x24\x91\x24\xcb\xaf\x42\xaf\xcd\x17\x89\xac\x1d\x5b\xb3\x34\x22\xd3\xe1\x4c\x64\x1c\x54\x42\x3a\x09\x9f\x95\xf9\x0d\xbe\xc6\x25\x7f\xc5\x7a\xfd\x8e\xed\x5b\x97\x6b\xe4\xd8\x3d\xa7\xdd\x4f\x6c\x5b\x3d\x9e\x07\x96\xb0\xf2\x24\xa9\xf1\x80\xe5\xd4\xcd\x3d\x8d\x43\xe2\x91\x7e\x48\x54\x92\x1a\x05\x5f\x3d\xe9\x7f\x26\x1a\xd5\x9d\xda\x0b\x5e\x0c\xa5\xc6\xff\x6e\x4b\x44\x11\xce\x38\x24\x02\x55\x3e\xf1\x7f\x1a\xca\x02\xc1\x1e\x6a\x34\x9f\xa9\xeb\x12\x06\x49\x3d\x7e\x0c\x81\x6e\x10\xff\xdf\xfe\x64\x53\x63\xab\xc7\x66\xb9\xea\xcb\x4a\x97\xd4\x1b\x4d\xc3\x25\xb6\xc3\x19\x85\x74\x12\xc6\x0c\xff\x06\x00\xa2\x14\xc4\xa4\x52\x02\x00\x00")
+
+func assetsTemplatesHelloGoTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsTemplatesHelloGoTmpl,
+		"assets/templates/hello.go.tmpl",
+	)
+}
+
+func assetsTemplatesHelloGoTmpl() (*asset, error) {
+	bytes, err := assetsTemplatesHelloGoTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/templates/hello.go.tmpl", size: 594, mode: os.FileMode(436), modTime: time.Unix(1792402917, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _assetsTemplatesJson_apiGoTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8c\x54\xc1\x8e\xdb\x36\x10\x3d\x8b\x5f\x31\xe5\xa1\x10\x5b\x55\x0b\x14\x3d\xa9\xf0\x61\x93\x18\x5b\xb7\xe8\x6e\x10\xbb\xe9\xa1\x28\x60\xae\x34\xb6\xd9\xc8\xa4\x42\x8e\xe4\x18\x82\xfe\xbd\x18\xd2\xf6\x1a\x01\x76\x37\x07\x41\x12\x67\xe6\xbd\x37\x8f\x1c\x76\xba\xfe\xa4\xb7\x08\xad\xdb\x9a\x5a\x88\x9b\x1b\xb8\x85\xdf\x97\x0f\xf7\x70\xfb\x7e\x01\x68\x9b\xce\x19\x4b\x15\xdc\xcd\x57\xe0\x91\x7a\x6f\x03\xd0\x0e\x21\x90\xf3\xd8\x80\x21\xdc\x17\xf0\xfe\x61\xb9\x82\x41\xb7\xa6\xd1\x84\x81\x31\x38\x25\xa2\x3c\xba\xe6\x08\xda\x36\x80\xf5\xce\x61\x00\x43\xf0\xa8\xeb\x4f\xa5\x10\x66\xdf\x39\x4f\x90\x8b\x4c\xa2\xad\x5d\x63\xec\xf6\xe6\xbf\xe0\xac\x14\x99\xb4\x48\x37\x3b\xa2\x4e\x0a\x25\xc4\xa0\x3d\x2c\xec\xc6\x41\x20\x6f\xec\x16\x66\xb0\x16\x23\x27\xe9\x3d\xca\x0a\xc6\x91\xab\xa0\xbc\xd7\x7b\x9c\xa6\x42\x64\x72\x40\x1f\x8c\xb3\x57\xb1\x8f\x69\x25\x85\x1b\x57\x4b\x78\x8a\xbd\x73\xf5\x34\x89\x49\xac\x85\xa0\x63\x87\xb0\x20\xdc\x33\x55\x5f\x13\x8c\x22\x63\x58\x38\x53\xaf\xb9\xa4\x4a\xcc\x6b\x91\x7d\xd4\x6d\x8f\x60\x2c\x01\xc0\x39\x36\xf0\x9a\x5c\x8b\xe9\x04\xf7\xd6\x59\xc2\x2f\x74\x42\x1c\x27\x21\x36\xbd\xad\x61\x61\x0d\xe5\x0a\x2e\xff\x4b\xd2\x9e\x17\xf2\x53\x7e\x01\xe8\xbd\xf3\x8a\x25\x24\xdf\xe1\x14\x19\xa7\x02\xac\x69\xc5\xb9\xf2\xe0\x0d\x21\x7b\x9d\x1f\x80\x3d\x2b\x3f\x60\xe8\x9c\x0d\xf8\x37\x07\x7c\x01\x81\x34\xf5\x81\x65\x16\x30\xf0\x0b\xfd\x46\xd7\x38\x4e\x2a\x71\x30\xc5\xa1\xfc\x0d\x75\x83\x3e\x57\xe5\x12\x29\x97\x91\xcb\xd2\x4f\xab\x63\x87\xb2\x00\xa9\xbb\xae\x35\xb5\x26\xe3\x6c\xda\x24\xc5\x35\x91\xe1\x54\x98\x58\xd4\x45\x2d\x67\x95\xf7\x78\x98\xf3\xde\xa2\xcf\x0f\xaa\x4c\x9f\xf9\xa0\x2e\xda\x3f\xf4\x36\xaf\xe9\xcb\xb9\xb5\xd8\x34\x3f\xce\x17\xf0\x4c\x37\x1e\x7e\x38\xad\x7f\xee\x31\xd0\x55\x0f\x66\xc3\xdf\xf0\xdd\x8c\xed\xe1\xa6\xb2\x2b\x6b\x8a\x84\xb6\x8c\x2a\x17\xec\x81\xd5\xed\x12\xfd\x80\x7e\x9e\xf8\xf6\xba\xfb\x27\x6d\xf3\xbf\xe9\x35\xca\x08\x2d\x2b\x90\xbd\xd5\x83\x36\xad\x7e\x6c\x51\x4e\x4a\x64\xe7\x26\xd1\x7b\x91\x4d\x22\x0b\x07\x43\xf5\x0e\x7c\xf9\x27\xd2\xce\x35\x4c\x5e\xeb\x80\x20\xef\xe6\x2b\x59\x3d\xe5\x3f\x27\xe8\xe1\x8f\x22\x9e\xbb\x91\x4f\x5b\x05\x12\x37\x1b\xb6\x3d\x9e\xaf\x0a\x7e\xf9\x99\x39\x13\x20\x8f\x5a\x44\xe4\xa9\xe0\xe9\x8b\x75\x22\x3b\x77\x5f\xcd\x2e\xce\xbf\xc3\xe4\xbc\x2f\xdf\xb8\xe6\xa8\xca\xf4\x9f\x7f\xcf\x55\xea\xd7\xaf\xbd\x7a\x4d\xe2\x1b\xdd\x9c\x2c\x7f\xd9\x2a\x63\xe3\x45\x10\xef\x90\x0a\x24\xfc\xc8\x44\x65\xf4\x38\x57\xdc\x07\xfb\xc5\x6a\x59\x46\x1c\x5a\x98\xcd\x40\xca\x6f\x12\xf1\x97\xed\xbc\xab\x31\x04\xde\x89\xb9\x25\x43\xc7\x97\xd5\xf0\xa4\x82\x09\xe0\xf1\x73\x6f\x3c\x36\xf2\xa2\xe0\x15\xa6\xb7\x1e\x35\x61\x53\xc4\x1b\x4e\xc5\x4d\xfe\x7a\x46\x6e\xdb\xd6\x1d\x78\x38\xee\xe6\xab\x74\x07\x4a\x25\x5e\xc3\x4d\x07\xe4\xde\x51\xac\xc6\xe6\x65\xf9\xfb\x98\x0d\xd6\x11\xe8\x94\x2f\xa7\xa7\xe9\x59\x92\xeb\xae\xc7\x47\xc1\x08\x1e\xa9\xf7\x16\x26\xf1\xff\x00\x16\x92\xe4\x6f\xd8\x05\x00\x00")
+
+func assetsTemplatesJson_apiGoTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsTemplatesJson_apiGoTmpl,
+		"assets/templates/json_api.go.tmpl",
+	)
+}
+
+func assetsTemplatesJson_apiGoTmpl() (*asset, error) {
+	bytes, err := assetsTemplatesJson_apiGoTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/templates/json_api.go.tmpl", size: 1496, mode: os.FileMode(436), modTime: time.Unix(1792402412, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _assetsTemplatesReverse_proxyGoTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x93\x4d\x6f\x9b\x4c\x10\xc7\xcf\xec\xa7\x98\x67\x0f\x8f\x20\x42\xb8\xbd\x45\x48\x1c\xd2\xd6\x79\x91\xd2\xd4\xc2\x49\x7b\x4c\xb6\x30\xb6\xb7\xc5\xbb\x74\x76\xc0\xb6\x10\xdf\xbd\x5a\x30\xb6\xdb\x43\x0f\xa0\x65\x5e\xff\xff\x1f\x50\xab\xe2\xa7\x5a\x23\x54\x76\xad\x0b\x21\x66\x33\xb8\x01\xc2\x16\xc9\x21\xd4\x64\xf7\x87\x14\xfc\xd3\x01\x08\x7f\x35\xe8\x18\xb4\x83\x95\xa5\x9d\xa2\x12\x4b\x60\x0b\xbc\x41\x68\x6a\xc7\x84\x6a\x0b\x0e\xd9\x8f\xd0\x66\x08\xcf\x6f\x6f\xe7\xaf\x2f\x8b\xe5\x73\x3e\xbf\xf9\x0c\x68\x5a\x4d\xd6\x6c\xd1\x30\xb4\x8a\xb4\xfa\x5e\x61\x22\x84\xde\xd6\x96\x18\x42\x11\x48\x83\x3c\xdb\x30\xd7\xf2\xe2\x3c\xdc\x1a\xd6\xd5\x14\x6c\x68\x38\x5a\x27\x45\x24\x44\xab\x08\x1e\xcc\xca\x82\x63\xd2\x66\x0d\x19\xbc\x89\xce\x57\xaa\x2d\xca\x14\xba\xee\x87\xb3\x06\x92\x27\xb5\xc5\xbe\x8f\x45\x20\xbd\x33\x6d\xcd\x45\xee\xeb\x18\x19\xd3\xa5\x2d\x24\x9c\x73\x9f\x6c\xd1\xf7\xa2\x17\x6f\x42\xf0\xa1\x46\xf8\x68\x0d\xe3\x9e\xfd\xb6\xa6\x60\xe8\x44\x30\x30\x82\xab\x49\x65\x92\x7b\x5a\x0e\x17\x3e\x2c\xfa\x51\xe0\x09\xcf\x55\x43\x55\xf2\x92\x3f\x0a\xb1\x6a\x4c\x01\x0f\x46\x73\x18\xf9\x29\xaa\x2c\x09\x9d\x83\x34\x03\xeb\x92\x3b\x64\x34\x6d\x28\xff\xe0\x27\x23\x11\xe8\x15\x4c\x95\x59\x06\x52\xfa\xd6\x53\x6f\x06\xd2\xab\x48\x67\xb3\xca\x16\xaa\xda\x58\xc7\xe9\xf5\xbb\xeb\xf7\x52\x04\xbd\x08\x26\x0d\x31\xbc\x42\x06\x5e\xc7\x42\x91\xc3\xf0\xd8\x1d\x79\xad\x83\xa8\x25\x2b\xf2\xaa\xc2\xa3\xd7\x18\x90\xc8\xd2\x20\x53\xaf\xce\x5e\xb2\x0c\x8c\xae\x7c\x34\x20\xe4\x86\xcc\x04\xa7\xeb\x63\xf8\xdf\x2f\x98\xfb\xbe\xee\x4b\x9d\x82\xac\xfd\x2e\x19\xc3\x4b\xfe\x98\xfe\xc3\x61\x0c\x73\xa2\xa1\x60\x4e\xf4\x60\x5a\x55\xe9\xb2\x1f\xe4\xff\xb5\xe2\x84\xfb\x09\x77\x4b\x6d\xd6\x15\xde\x5b\xc7\x97\xec\xc3\x49\x68\xd4\xc7\x5e\xe8\xc9\x5f\xde\x98\xb0\xe0\xfd\x34\x6a\xb0\xe7\x2f\x4b\x31\xec\xc0\x0f\x4e\x72\x74\xb5\x35\x0e\xbf\x91\x66\xa4\x18\x68\x7c\xbf\x49\x3e\xfe\x02\xd1\x58\x7e\x04\xe2\xdb\xff\x3b\xb3\x18\x0a\x07\xe7\xe1\x2e\x06\x79\x34\x71\xc2\x26\xe3\x71\xc5\x92\x15\x37\xee\x83\x2a\xef\x14\xe3\x4e\x1d\xa2\x33\x46\x24\x1a\x2c\x17\xbc\x4f\x86\xaf\x2b\x59\x22\xb5\x78\xff\xfc\xbc\xf0\x23\x29\x3a\xd1\xb8\xb4\xb5\x64\x5b\x5f\xfa\x8a\xa0\x03\x42\x6e\xc8\x40\x2f\x7e\x0f\x00\x3c\x11\xf6\x8d\xe5\x03\x00\x00")
+
+func assetsTemplatesReverse_proxyGoTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsTemplatesReverse_proxyGoTmpl,
+		"assets/templates/reverse_proxy.go.tmpl",
+	)
+}
+
+func assetsTemplatesReverse_proxyGoTmpl() (*asset, error) {
+	bytes, err := assetsTemplatesReverse_proxyGoTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/templates/reverse_proxy.go.tmpl", size: 997, mode: os.FileMode(436), modTime: time.Unix(1792402412, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _assetsTemplatesScheduledGoTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x54\xc1\x8e\xdb\x46\x0c\x3d\x6b\xbe\x82\x99\x4b\xa4\x42\x2b\xdf\x5d\xf8\x10\x24\x0b\x74\x5b\xd4\x1b\xac\xdd\xf6\x6a\x45\xa2\xed\xd9\x95\x86\x2a\x45\x59\x31\x5c\xfd\x7b\xc1\x19\xd9\x71\xda\x1c\x16\x2b\x0f\x1f\xdf\x7b\x1c\x3e\xa9\x2b\xab\xb7\xf2\x80\xd0\xd0\xc1\x55\xc6\x2c\x16\xf0\x01\xfa\xea\x88\xf5\xd0\x60\x0d\xaf\xf4\x65\x09\x78\x42\x3e\x83\xf3\x27\xaa\x4a\x71\xe4\x81\x07\xdf\x83\x1c\x51\xcb\x40\xbe\x42\x28\x7d\x0d\x8c\x1d\xb1\xf4\x4a\xa1\x35\x1a\xa4\xa2\x16\xa1\xec\xe1\xd7\xcd\xf3\xba\x80\x2d\xbb\xc3\x01\x19\x9c\xc0\x9e\xa9\x85\x8a\xc9\xc3\xe8\xe4\x18\xa8\xc8\xe3\x43\x7f\x24\x81\x96\x6a\xcc\x95\x64\x87\xd5\x91\xe0\xfd\xc5\xb6\x28\x47\xaa\xed\x12\xec\xe7\xe7\xcd\xd6\x4e\xef\xe1\x1f\x28\x16\xaa\xfd\xf0\xa0\xae\xde\x10\x1e\x76\x39\x10\x47\xde\xd2\x9f\xb5\xfd\x3a\x04\x43\x8f\xbe\x76\xfe\x00\x25\x68\x3f\x30\xfe\x3d\x60\x2f\x85\x31\xae\x55\xc7\x90\x9a\xc4\xa2\xaf\x48\x41\x8b\xd7\x9e\xbc\x35\x89\xdd\xb7\xa2\xff\x3c\xca\xe2\x28\xd2\xe9\xb3\xb8\x16\xad\xc9\x8c\x39\x95\x0c\x4f\x7e\x4f\xd0\x0b\x2b\xf1\x0a\x76\xe6\xa2\xe0\xb2\x45\xbb\x84\xcb\x45\x49\xa0\x58\x97\x2d\x4e\x53\x6e\x12\x7b\x42\xee\x1d\xf9\xbb\xda\x9f\xf1\x24\x96\x6b\xaa\x2c\x7c\xab\x7d\xa2\x6a\x9a\xcc\x64\x76\xc6\xc8\xb9\xc3\xf9\x62\x55\x6c\xa8\x04\x2e\x26\xd9\x48\x29\x43\x0f\x70\xd5\x07\x80\x9d\xb6\x2e\x6d\x1f\x2a\x76\x17\x30\x2c\x58\x03\xa8\xeb\x62\xeb\x5a\xbc\xc3\x68\x45\x41\x9f\x06\x8e\x1b\xfd\x1f\x51\x3d\x57\x14\xf5\xc8\x4c\x0c\x3f\x92\x43\xad\xe4\xd4\x3a\xc1\xb6\x93\xb3\xdd\x99\x69\xf6\xfc\x91\xbc\xe0\xd7\xab\xe9\xcb\x64\xcc\x7e\xf0\x15\x3c\x79\x27\x69\x06\xb7\xdf\xc1\x65\x9a\x41\x3a\xe3\x73\x08\x94\x99\x4e\xc9\x28\x03\x7b\x98\x2b\x97\x29\x07\xef\x1a\x15\x58\x2c\x42\xf0\x5c\xcc\x60\x59\xc9\x50\x36\x30\x12\xbf\xe5\x7a\x55\x4d\x59\xa1\x66\x2c\x24\xeb\x4c\x03\x03\x8d\xbe\x88\xf2\xaf\xf4\x25\xcd\xa2\x84\x2a\xec\x5b\x29\x3e\xb3\xf3\xd2\xf8\xd4\xbe\x0c\xde\xeb\x65\xce\xb9\xb6\xd9\xcd\xc1\x2c\x1b\x18\x5e\x06\x9f\x56\xf2\xf5\xea\x2a\xf8\xd5\x3f\xe2\x1c\x46\xd0\xa4\x14\x2f\xd8\x77\xe4\x7b\xfc\x8b\x9d\x20\xe7\xc0\xf0\xd3\x7c\x1e\x72\x77\xa7\xef\xf6\xc0\xc5\xef\x21\xdd\xf0\x6e\x35\xc7\x5b\x8d\x25\x63\xf1\x0b\x96\x35\x72\x9a\x15\x1b\x94\xd4\x7e\x68\x1a\x1a\x6d\x3e\x43\x32\x93\x24\x81\x32\x6c\x26\x1d\x73\xb0\x33\x8b\x27\x81\x52\xb1\x58\xdb\x3c\xda\x89\x61\x89\xf5\x35\x49\x60\xc2\x5a\x29\xee\xc6\x4b\x26\x9d\xb6\x83\xe5\x6a\x4e\xdb\x25\xb6\x2d\xc1\xd2\x9b\xcd\x61\x4e\xd3\x32\xa6\x69\x4d\x63\x9a\x15\x7f\x6c\x3f\xa6\xd9\x64\x92\x98\x39\x6d\xbd\xd3\x7b\xfe\xcd\x24\x6e\xaf\xa3\xc2\x6a\xa5\x12\x61\xac\xf0\x53\x97\x97\x66\x26\x99\x6e\x88\x77\xdf\x10\x8c\x57\x06\x58\x81\xdd\x97\xae\xc1\xda\xce\xe7\x61\x5a\x58\x69\x4b\x7c\x56\x96\xab\xfc\x77\xea\x4f\x5e\x90\x7d\xd9\x6c\x90\x4f\xc8\x01\x7b\x1d\xb1\xb8\x65\x7e\x15\x87\xd9\x38\x5f\x61\x3a\xeb\xea\x8b\x91\x15\x9b\xf0\x36\x28\xfb\x7f\xf7\x10\xd6\xee\xe5\x61\x7b\xee\x50\xd7\x51\x76\x5d\xe3\xe2\x57\x31\x7e\x39\x42\x4f\xd8\xfc\xdc\x18\xed\x65\x71\x56\xfd\xc8\xe0\x23\xb3\x5e\x96\xc2\x8b\x35\x8e\x8f\xe1\x90\xd3\x31\x2b\xe2\xa3\x7a\xc9\x7e\xbe\x03\x7f\x77\x3d\x61\x65\xb7\xda\x3c\x55\x3c\x64\xbe\xc5\x74\x23\xd4\xdd\xe7\x34\x83\x0b\x30\xca\xc0\x1e\x26\xf3\xef\x00\x5e\x8a\x2d\xbe\xf3\x05\x00\x00")
+
+func assetsTemplatesScheduledGoTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsTemplatesScheduledGoTmpl,
+		"assets/templates/scheduled.go.tmpl",
+	)
+}
+
+func assetsTemplatesScheduledGoTmpl() (*asset, error) {
+	bytes, err := assetsTemplatesScheduledGoTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/templates/scheduled.go.tmpl", size: 1523, mode: os.FileMode(436), modTime: time.Unix(1792402412, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _assetsTemplatesStaticGoTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x52\x61\x6b\xdc\x46\x10\xfd\xac\xfd\x15\xe3\xfd\x10\xa4\x20\x4b\x0e\x0d\xa5\x5c\x4f\x06\x13\x5f\xe3\x40\xeb\x06\xdb\x69\x29\x8e\x69\xd6\xd2\xe8\xa4\x5a\xb7\xab\xce\x8e\x72\x77\x08\xfd\xf7\x30\x92\xce\xb1\x83\x0f\x8e\xe3\xe6\xbd\x99\x79\xf3\xf6\xb5\x26\x7f\x30\x6b\x84\xc6\xad\xeb\x5c\xa9\x34\x85\x33\xf0\x6c\xb8\xce\x81\xd0\xb7\xce\x16\x48\x0b\xa8\x19\x8c\xf5\x5b\x24\x0f\xdb\x9a\x2b\x28\xeb\x1d\x16\x90\x3b\xcb\x68\x19\x4a\x47\x60\xc0\x23\x83\x2b\x65\x42\x6b\xb8\xf2\x60\x6c\x31\x91\xdf\x9e\xbc\x9d\x28\x76\xcf\x55\x6d\xd7\x80\x8d\xc7\x44\xa9\x7a\xd3\x3a\x62\x08\x55\xa0\x2d\x72\x5a\x31\xb7\x5a\x45\x4a\x7d\x35\x04\x1f\x6c\xe9\xc0\x33\x09\x3d\x83\x2f\xaa\x17\x92\xd9\xa0\x5e\x40\xdf\xff\xe7\x9d\x85\xe4\xd2\x6c\x70\x18\x62\x15\xe8\xaf\x48\xbe\x76\xf6\x09\xf6\xd7\x54\x99\xe0\xc2\xe5\x1a\xbe\x63\xe7\x2e\x1f\x06\x35\xa8\x2f\x4a\xf1\xbe\x45\x39\xd3\x75\x94\xa3\xac\xeb\x72\x86\x5e\x05\xf3\x61\x37\x02\x4f\x22\x54\x70\xef\x8a\x3d\xcc\x9f\xb9\x36\x4c\x5a\x0f\x03\x3c\x64\xb0\x31\xed\xed\x84\xde\x1d\xca\x22\x3d\xd5\x8b\x43\x2f\x00\xf4\x9a\x71\x27\x07\x6f\x9a\x5f\x21\xaf\x0c\x79\xe4\xac\xe3\xf2\xf8\x17\x1d\x83\x5e\x1e\x9d\xff\xf9\xee\xe6\x9f\x8f\x2b\x10\xc2\xe9\x67\xbb\x1c\x7f\x97\x22\xe0\x74\x59\xbd\x39\xbd\xc0\xa6\x71\x50\x92\xdb\xc0\xaa\x2c\x71\x99\x56\x6f\x4e\x97\xe9\x04\xa7\x73\x8f\x1e\x2f\x4f\xc9\xdd\x3b\xf6\x09\xef\x58\xcc\x99\xd6\xb6\x8d\xa9\xed\x0b\x7b\x3f\x79\xa4\x63\xb3\x46\xcb\x0b\x78\xfd\xd9\x9e\xd7\xde\x34\x8d\xdb\x2e\xa6\x61\xc3\xec\xd6\x3b\xb1\x66\xc7\xb3\x59\xfd\xa0\x54\xd9\xd9\x1c\x3e\xd8\x9a\xc3\x08\x1e\xff\x5f\xb3\x21\x29\x84\x33\x3f\x06\x24\x72\x14\x89\xbb\x84\xdc\x91\x85\x19\xe9\x87\x18\x6c\xdd\xa8\x43\xe7\x55\x67\xc3\x9c\x77\x07\x78\x6c\x94\xaf\xa3\x18\xb6\x20\x21\x49\xae\xc6\x60\x7a\xfc\x9b\x6a\x46\x8a\x81\xe0\xf5\x5c\xff\xbf\x43\xcf\xd1\x44\x97\x55\x75\x09\x94\xfc\x81\x5c\xb9\x02\x8e\x32\xd0\xef\x57\x37\x1a\x5e\xbd\x7a\x5e\xbc\x58\x9d\x9d\x6b\x61\x07\xdb\xe4\x02\x4d\x81\x14\x46\xc9\x35\x72\xa8\xcf\xc4\x00\x79\x93\xf7\xab\x9b\x18\x46\x5e\xa4\x82\x60\x5c\xb6\x92\x1d\xe1\x36\x06\x3d\x8f\xb2\x8e\x61\x74\x0c\x0b\x1d\x4f\x42\xaf\xd9\x70\xe7\x27\xfc\xd2\xf1\x38\x0e\x0b\x19\x31\x7b\x20\x87\x07\x83\x58\xe2\x63\x70\x0f\xb0\xc8\xbe\xa7\xe9\x96\x92\x4f\x57\xbf\x27\x1f\x0d\x57\x77\xe3\x25\x47\xee\x61\x54\x39\x8e\xbe\x74\xfc\x9b\xeb\x6c\x21\x0a\xe8\x85\x89\x3f\x9e\x32\xda\x69\xf9\x58\x32\xad\x63\xd9\x92\x3c\x49\x79\xf4\x42\x83\xc9\x2b\x3c\x96\x36\x72\x8d\x78\xd0\x76\xf7\x4d\x9d\xc7\xb0\x31\x3b\x89\x49\xf6\xd3\xcf\x27\x27\x3a\x7a\xee\x71\xf6\xcc\xce\x1f\x24\xfd\x3b\xbd\x65\x06\xdb\x64\x7c\xb9\xf0\xf6\xee\x7e\xcf\x18\x8a\x16\x49\x6f\x14\x3d\x66\x03\x89\x1e\x03\x71\xcd\xae\x7d\x9a\x88\x08\x7a\x20\xe4\x8e\x2c\x0c\xea\xdb\x00\x82\x2d\x1b\x6d\xbf\x04\x00\x00")
+
+func assetsTemplatesStaticGoTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsTemplatesStaticGoTmpl,
+		"assets/templates/static.go.tmpl",
+	)
+}
+
+func assetsTemplatesStaticGoTmpl() (*asset, error) {
+	bytes, err := assetsTemplatesStaticGoTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/templates/static.go.tmpl", size: 1215, mode: os.FileMode(436), modTime: time.Unix(1792402412, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _assetsTemplatesWebhookGoTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8c\x55\x4b\x6f\xdb\x46\x10\x3e\x6b\x7f\xc5\x84\x87\x82\x2c\x68\x2a\x35\x60\x1f\xdc\xe8\x60\x3b\x42\xec\xb6\x7e\xc0\x74\xea\x02\x41\xd0\xac\x97\x43\x71\x1b\x72\x57\xd9\x1d\xea\x51\x41\xff\xbd\x98\x25\xf5\x70\x2c\xa0\x39\x89\x3b\xcf\x6f\xbe\x79\x68\x2a\xd5\x57\x39\x41\xa8\xed\x44\x2b\x21\x86\x43\x38\x87\x39\x3e\x57\xd6\x7e\x05\x87\x0a\xf5\x0c\xdd\x19\x68\x02\xa9\x14\x4e\xc9\xc3\xfd\x5d\xfe\x08\x0e\xbf\xb5\xe8\xc9\x83\xd7\x13\x83\x05\xcc\x35\x55\x70\x75\x73\x7e\x79\x94\x5f\x9d\x1f\x9f\x9c\x72\x9c\xd6\x6b\x33\x01\xaa\x10\x3c\x2a\x87\x04\xda\x84\xd7\xd3\xf8\xe2\xea\xee\xee\xf7\xbf\xf3\xf1\xe5\xc3\xf8\x11\xd0\xcc\xb4\xb3\xa6\x41\x43\x30\x93\x4e\xcb\xe7\x1a\x53\x36\xe4\x18\x1c\x5e\x52\xeb\x10\xb4\x07\x87\xb2\x80\xd2\xd9\x86\xb5\xf0\xd7\xd1\x55\xfb\x7c\x94\x6f\x0c\x8e\x8e\x4f\x4e\xa1\x42\x59\xa0\xcb\x84\xd0\xcd\xd4\x3a\x82\x58\x0c\x22\xe5\x96\x53\xb2\xc3\xaa\x91\x2a\xda\x3d\x7d\x25\x8f\x4f\x4e\x59\x80\x46\xd9\x42\x9b\xc9\xb0\xc2\xc5\x8b\xf7\x3f\xde\x9a\x20\x70\xce\x3a\xcf\x5f\x65\x43\xfc\xa3\xed\x50\xdb\x96\x74\xcd\x0f\x83\x34\xac\x88\xa6\xfc\x6d\x83\x95\x27\xa7\xcd\xc4\x47\x22\x11\x62\x26\x1d\x5c\x9b\xd2\x42\x27\x84\x11\x7c\x11\x2b\xf6\x92\x0d\x46\x67\xb0\x5a\x71\x12\xc8\x6e\x65\x83\xeb\x75\x2a\x06\xd1\x0c\x9d\xd7\xd6\xec\xe9\xfe\xec\x24\x9d\xba\xb0\x2a\x82\x9d\xee\xbd\x55\xeb\xb5\x58\x8b\x2f\x42\x28\x6b\x3c\x41\x23\x17\xf7\x72\x59\x5b\x59\xc0\x08\x7e\x81\x77\xef\xe0\xf8\x6d\x87\xa2\xef\xc1\xa7\xcf\xcf\x4b\x42\x21\x68\x39\x45\xb8\xb4\x86\x70\x41\x0c\xae\x55\xb4\x5a\x0b\x51\xb6\x46\xc1\xb5\xd1\x14\x27\xb0\x12\x83\xde\x69\xd4\xbb\xc5\xd6\x67\x1f\x90\xd0\xcc\xe2\xe8\x65\x17\xa3\x24\x11\x1b\xf7\x9c\xa4\x63\xff\xb8\x0f\x9f\x42\x60\x30\x04\xd4\x25\xd4\x68\xe2\x2e\x6e\x02\xa3\x11\xbc\x65\xf1\xc0\x21\xb5\xce\x6c\x00\xad\xd6\xbd\x8f\xcf\x6e\x71\xfe\x7d\x2e\x1e\x05\x63\x09\x3c\x52\x94\x88\xc1\x5a\x1c\xf0\x36\xba\xde\xe2\x99\xc9\x5a\x17\xdb\x39\x89\xa7\x3d\x3f\x5d\x49\xe9\xde\x88\x75\x3d\x4a\xe0\xd9\xda\x9a\x51\xe1\x62\x8a\x8a\xb0\x08\x60\xe0\x6c\x04\x15\x2e\xb2\xf7\xa8\x6c\x81\x79\x30\x8d\xfb\x56\x67\x8f\x4e\x37\xf7\x0e\x4b\xbd\x88\xb7\xe1\x52\x88\xba\x21\x1b\x31\x39\x03\x5d\x86\x28\x6f\x46\x60\x74\xbd\x5f\x73\x29\x6b\x8f\xa1\x8c\x46\xaa\x90\xa5\x91\x2a\xd4\xdd\xb9\xf3\x67\xda\xb7\x2f\x11\x03\x56\x3e\x39\x4d\xdb\x42\x92\x6d\xfd\x3c\xe2\xd9\xf8\x5b\x2b\xeb\x98\xbf\xf2\xb6\x89\x8d\xae\x93\x14\x36\x85\xec\x7a\xf4\xd0\x9a\x58\xd1\x62\x43\x59\x57\x61\xa0\x3c\x85\x39\xf0\x3c\x67\x0f\xe8\xa7\xd6\x78\x0c\xc9\x5c\x0a\x0e\x7e\xee\xe5\x61\xf5\x13\x76\xb1\x0e\x56\x87\x4a\x0b\x86\x63\xd6\xc7\xf3\x14\xa2\xa7\xfe\x9e\x70\xd7\x94\x35\xa5\x9e\xb4\x0e\x8b\x28\xed\x12\xe5\x24\xa9\xf5\xd7\x86\xd0\x19\x59\xe7\xe8\x66\xe8\x82\x6f\xb2\x23\x09\x9d\x0b\x14\xe9\x12\x5c\x76\x83\x54\xd9\x82\xf3\x45\x7c\x8c\x22\xc6\x30\x98\x67\x57\x61\xf9\xe3\x24\xcb\x91\xe2\xe8\xbc\xae\xed\x3c\x4a\x7b\x93\xe4\x35\xa8\x3e\x0a\x63\x92\x6c\xfb\x3d\xa0\x4e\x7f\x6b\x29\x44\xc2\x62\x0f\x0d\x4f\x17\xa3\xe9\x3b\xb0\x9d\x8f\xee\x2a\x64\x0f\x28\x8b\xf3\xba\x8e\x43\xc2\x1b\xb9\xb8\x58\x12\x7a\x16\x62\xc8\xec\xb2\x0b\x5b\x2c\xd3\xbd\x5d\x4d\x92\x1f\x20\x71\xb3\xd7\x64\x2d\xd4\xd2\x4d\xf0\x25\xdc\xbe\x2d\x63\x43\x9a\x96\x8f\xd6\xfe\xc1\x26\x07\x30\xeb\x12\xde\x1c\xde\x08\x46\xd6\x71\xc8\x4b\x1e\x47\x07\x0e\x6c\x94\x24\x87\xa0\x5d\x9b\x10\x71\xb7\x4a\x2f\xa1\x7d\x34\xb2\xa5\xca\x3a\xfd\xef\x41\x16\xf9\x3a\xe1\x8c\xcf\x7f\x23\xa7\x9f\xba\x95\xfa\xac\x79\x1c\x4a\xa9\x70\xb5\xde\x72\x73\x36\x02\x3e\x7b\xd9\x47\xd3\x48\xe7\x2b\x59\xef\x90\xff\x14\x02\x24\xbf\xfe\x0f\x87\x1b\xa0\xbf\xe5\x77\xb7\x2f\x31\x5e\xc8\x62\x33\xd8\xaf\x11\x96\x0d\x65\xf7\x4e\x1b\xaa\x4d\x1c\x3d\x74\x7f\x8a\x05\x84\x94\x67\xd1\x6b\xda\x3e\x68\x62\xe6\xc6\xac\x0f\xbb\x3f\xcf\xc2\x12\xf5\x03\xba\x97\xf5\xd6\x86\x05\x34\xb4\x5b\xe2\xfd\xcb\x95\x93\x9d\xee\xaf\x69\x02\x2b\x70\x48\xad\x33\xb0\x16\xff\x0d\x00\xd5\xd8\x3f\xbc\xb9\x07\x00\x00")
+
+func assetsTemplatesWebhookGoTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_assetsTemplatesWebhookGoTmpl,
+		"assets/templates/webhook.go.tmpl",
+	)
+}
+
+func assetsTemplatesWebhookGoTmpl() (*asset, error) {
+	bytes, err := assetsTemplatesWebhookGoTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "assets/templates/webhook.go.tmpl", size: 1977, mode: os.FileMode(436), modTime: time.Unix(1792402412, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -162,7 +372,17 @@ func AssetNames() []string {
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
 	"effe/effe.go": effeEffeGo,
-	"effe/logic/logic.go": effeLogicLogicGo,
+	"assets/docker/alpine.Dockerfile.tmpl": assetsDockerAlpineDockerfileTmpl,
+	"assets/docker/distroless.Dockerfile.tmpl": assetsDockerDistrolessDockerfileTmpl,
+	"assets/docker/scratch.Dockerfile.tmpl": assetsDockerScratchDockerfileTmpl,
+	"assets/harness.go.tmpl": assetsHarnessGoTmpl,
+	"assets/templates/form.go.tmpl": assetsTemplatesFormGoTmpl,
+	"assets/templates/hello.go.tmpl": assetsTemplatesHelloGoTmpl,
+	"assets/templates/json_api.go.tmpl": assetsTemplatesJson_apiGoTmpl,
+	"assets/templates/reverse_proxy.go.tmpl": assetsTemplatesReverse_proxyGoTmpl,
+	"assets/templates/scheduled.go.tmpl": assetsTemplatesScheduledGoTmpl,
+	"assets/templates/static.go.tmpl": assetsTemplatesStaticGoTmpl,
+	"assets/templates/webhook.go.tmpl": assetsTemplatesWebhookGoTmpl,
 }
 
 // AssetDir returns the file names below a certain
@@ -205,11 +425,25 @@ type bintree struct {
 	Children map[string]*bintree
 }
 var _bintree = &bintree{nil, map[string]*bintree{
+	"assets": &bintree{nil, map[string]*bintree{
+		"docker": &bintree{nil, map[string]*bintree{
+			"alpine.Dockerfile.tmpl": &bintree{assetsDockerAlpineDockerfileTmpl, map[string]*bintree{}},
+			"distroless.Dockerfile.tmpl": &bintree{assetsDockerDistrolessDockerfileTmpl, map[string]*bintree{}},
+			"scratch.Dockerfile.tmpl": &bintree{assetsDockerScratchDockerfileTmpl, map[string]*bintree{}},
+		}},
+		"harness.go.tmpl": &bintree{assetsHarnessGoTmpl, map[string]*bintree{}},
+		"templates": &bintree{nil, map[string]*bintree{
+			"form.go.tmpl": &bintree{assetsTemplatesFormGoTmpl, map[string]*bintree{}},
+			"hello.go.tmpl": &bintree{assetsTemplatesHelloGoTmpl, map[string]*bintree{}},
+			"json_api.go.tmpl": &bintree{assetsTemplatesJson_apiGoTmpl, map[string]*bintree{}},
+			"reverse_proxy.go.tmpl": &bintree{assetsTemplatesReverse_proxyGoTmpl, map[string]*bintree{}},
+			"scheduled.go.tmpl": &bintree{assetsTemplatesScheduledGoTmpl, map[string]*bintree{}},
+			"static.go.tmpl": &bintree{assetsTemplatesStaticGoTmpl, map[string]*bintree{}},
+			"webhook.go.tmpl": &bintree{assetsTemplatesWebhookGoTmpl, map[string]*bintree{}},
+		}},
+	}},
 	"effe": &bintree{nil, map[string]*bintree{
 		"effe.go": &bintree{effeEffeGo, map[string]*bintree{}},
-		"logic": &bintree{nil, map[string]*bintree{
-			"logic.go": &bintree{effeLogicLogicGo, map[string]*bintree{}},
-		}},
 	}},
 }}
 
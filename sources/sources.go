@@ -1,4 +1,16 @@
 package sources
 
-var Logic = string(MustAsset("effe/logic/logic.go"))
 var Core = string(MustAsset("effe/effe.go"))
+var Harness = string(MustAsset("assets/harness.go.tmpl"))
+
+// Template returns the source of the built-in effe template `name`.
+func Template(name string) (string, error) {
+	source, err := Asset("assets/templates/" + name + ".go.tmpl")
+	return string(source), err
+}
+
+// Dockerfile returns the built-in Dockerfile template `variant`.
+func Dockerfile(variant string) (string, error) {
+	source, err := Asset("assets/docker/" + variant + ".Dockerfile.tmpl")
+	return string(source), err
+}
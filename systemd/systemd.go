@@ -0,0 +1,133 @@
+package systemd
+
+import (
+	"fmt"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/commons"
+	"github.com/siscia/effe-tool/naming"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func logError(path, msg string) {
+	fmt.Println("File: " + path + " | " + msg)
+}
+
+// serviceUnit is the service activated by the socket, the effe
+// receives the socket from systemd thanks to `-listen systemd`.
+func serviceUnit(unit, name, version, execPath, user string) string {
+	service := `[Unit]
+Description=Effe ` + name + ` version ` + version + `
+Requires=` + unit + `.socket
+After=network.target
+
+[Service]
+ExecStart=` + execPath + ` -listen systemd
+Restart=on-failure
+`
+	if user == "" {
+		service += "DynamicUser=yes\n"
+	} else {
+		service += "User=" + user + "\n"
+	}
+	return service + `
+[Install]
+WantedBy=multi-user.target
+`
+}
+
+// socketUnit is the socket that systemd listen on, `listen` is
+// anything accepted by ListenStream: a port, an host:port or
+// the path of a unix socket.
+func socketUnit(name, version, listen string) string {
+	return `[Unit]
+Description=Socket for effe ` + name + ` version ` + version + `
+
+[Socket]
+ListenStream=` + listen + `
+
+[Install]
+WantedBy=sockets.target
+`
+}
+
+// generateUnits writes the `.service` and the `.socket` units for the
+// compiled effe at `path` into `dirOut`.
+// The name and the version of the units come from the effe Info.
+// If `binDir` is not empty it is the directory where the effe will be
+// installed, otherwise the absolute path of the effe is used.
+func generateUnits(path, dirOut, binDir, listen, user string) error {
+	log := func(msg string) {
+		logError(path, msg)
+	}
+
+	name, version, err := commons.GetNameVersion(path)
+	if err != nil || name == "" || version == "" {
+		log("Impossible to get name and version from the executable info.")
+		if err == nil {
+			err = fmt.Errorf("missing name or version in the info of %s", path)
+		}
+		return err
+	}
+
+	execPath, err := filepath.Abs(path)
+	if err != nil {
+		log("Error in getting the absolute path.")
+		return err
+	}
+	if binDir != "" {
+		execPath = filepath.Join(binDir, filepath.Base(path))
+	}
+
+	if err := os.MkdirAll(dirOut, 0777); err != nil {
+		log("Impossible to create the directory: " + dirOut)
+		return err
+	}
+
+	// the units are named like the executables
+	file, err := naming.FileName(name, version, log)
+	if err != nil {
+		log(err.Error())
+		return err
+	}
+	unit := "effe-" + file
+	servicePath := filepath.Join(dirOut, unit+".service")
+	if err := ioutil.WriteFile(servicePath, []byte(serviceUnit(unit, name, version, execPath, user)), 0644); err != nil {
+		log("Impossible to write the service unit: " + servicePath)
+		return err
+	}
+	socketPath := filepath.Join(dirOut, unit+".socket")
+	if err := ioutil.WriteFile(socketPath, []byte(socketUnit(name, version, listen)), 0644); err != nil {
+		log("Impossible to write the socket unit: " + socketPath)
+		return err
+	}
+	log("Everything went good, units created: " + servicePath + " " + socketPath)
+	return nil
+}
+
+// Generate is the main entry point, it creates the units of a
+// single executable or of every executable inside a directory.
+func Generate(c *cli.Context) {
+	path := c.Args().First()
+	f, err := os.Lstat(path)
+	if err != nil {
+		fmt.Println("File: " + path + " | Impossible to open the file, does it exists ?")
+		return
+	}
+	generate := func(path string) {
+		generateUnits(path, c.String("dirout"), c.String("bindir"), c.String("listen"), c.String("user"))
+	}
+	if f.IsDir() {
+		filepath.Walk(path, func(path string, f os.FileInfo, _ error) error {
+			if f.Mode().IsRegular() {
+				fmt.Println()
+				generate(path)
+			}
+			return nil
+		})
+	}
+	if f.Mode().IsRegular() {
+		generate(path)
+	}
+}
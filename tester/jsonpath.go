@@ -0,0 +1,70 @@
+package tester
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// jsonPath evaluates a simple JSONPath expression against a decoded
+// JSON document.
+// The supported syntax is the root `$` followed by any number of
+// `.key`, `['key']` and `[index]` selectors, e.g. `$.items[0].name`.
+func jsonPath(document interface{}, path string) (interface{}, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, errors.New("JSONPath must start with $: " + path)
+	}
+	current := document
+	rest := path[1:]
+	for rest != "" {
+		var key string
+		index := -1
+		switch {
+		case rest[0] == '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			key, rest = rest[:end], rest[end:]
+			if key == "" {
+				return nil, errors.New("Empty key in JSONPath: " + path)
+			}
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end == -1 {
+				return nil, errors.New("Unterminated selector in JSONPath: " + path)
+			}
+			key, rest = rest[2:end], rest[end+2:]
+		case rest[0] == '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, errors.New("Unterminated selector in JSONPath: " + path)
+			}
+			i, err := strconv.Atoi(rest[1:end])
+			if err != nil || i < 0 {
+				return nil, errors.New("Invalid index in JSONPath: " + path)
+			}
+			index, rest = i, rest[end+1:]
+		default:
+			return nil, errors.New("Invalid JSONPath: " + path)
+		}
+
+		if index >= 0 {
+			array, ok := current.([]interface{})
+			if !ok || index >= len(array) {
+				return nil, errors.New("No element " + strconv.Itoa(index) + " at " + path)
+			}
+			current = array[index]
+			continue
+		}
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("No object to select `" + key + "` at " + path)
+		}
+		if current, ok = object[key]; !ok {
+			return nil, errors.New("No key `" + key + "` at " + path)
+		}
+	}
+	return current, nil
+}
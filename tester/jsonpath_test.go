@@ -0,0 +1,70 @@
+package tester
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const document = `{
+	"name": "effe",
+	"items": [
+		{"name": "first", "tags": ["a", "b"]},
+		{"name": "second", "size": 2}
+	],
+	"nested": {"deeper": {"key.with.dots": true, "null": null}}
+}`
+
+func TestJSONPath(t *testing.T) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(document), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		path string
+		want interface{}
+		err  string
+	}{
+		{path: "$", want: doc},
+		{path: "$.name", want: "effe"},
+		{path: "$['name']", want: "effe"},
+		{path: "$.items[0].name", want: "first"},
+		{path: "$.items[1].size", want: float64(2)},
+		{path: "$.items[0].tags[1]", want: "b"},
+		{path: "$.items[0]['tags'][0]", want: "a"},
+		{path: "$.nested.deeper['key.with.dots']", want: true},
+		{path: "$.nested.deeper.null", want: nil},
+		{path: "$.nested.deeper", want: map[string]interface{}{"key.with.dots": true, "null": nil}},
+
+		{path: "$.missing", err: "No key `missing` at $.missing"},
+		{path: "$.items[0].missing", err: "No key `missing` at $.items[0].missing"},
+		{path: "$.items[2]", err: "No element 2 at $.items[2]"},
+		{path: "$.name[0]", err: "No element 0 at $.name[0]"},
+		{path: "$.items.name", err: "No object to select `name` at $.items.name"},
+		{path: "$.name.first", err: "No object to select `first` at $.name.first"},
+		{path: "name", err: "JSONPath must start with $: name"},
+		{path: "$..name", err: "Empty key in JSONPath: $..name"},
+		{path: "$.items[-1]", err: "Invalid index in JSONPath: $.items[-1]"},
+		{path: "$.items[first]", err: "Invalid index in JSONPath: $.items[first]"},
+		{path: "$.items[0", err: "Unterminated selector in JSONPath: $.items[0"},
+		{path: "$['name", err: "Unterminated selector in JSONPath: $['name"},
+		{path: "$name", err: "Invalid JSONPath: $name"},
+	}
+	for _, c := range cases {
+		got, err := jsonPath(doc, c.path)
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("%s: error %v, want %s", c.path, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", c.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %#v, want %#v", c.path, got, c.want)
+		}
+	}
+}
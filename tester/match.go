@@ -0,0 +1,122 @@
+package tester
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// expectation is what a fixture expects from the effe, every
+// field is optional and only the fields present are checked.
+type expectation struct {
+	Status       int                    `json:"status"`
+	Headers      map[string]string      `json:"headers"`
+	HeadersRegex map[string]string      `json:"headersRegex"`
+	Body         *string                `json:"body"`
+	BodyContains string                 `json:"bodyContains"`
+	BodyRegex    string                 `json:"bodyRegex"`
+	JSON         map[string]interface{} `json:"json"`
+	JSONRegex    map[string]string      `json:"jsonRegex"`
+}
+
+func sortedKeys(m interface{}) []string {
+	keys := []string{}
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func matchRegex(what, pattern, value string) string {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return what + ": invalid regex " + pattern + ": " + err.Error()
+	}
+	if !re.MatchString(value) {
+		return fmt.Sprintf("%s: %q does not match %s", what, value, pattern)
+	}
+	return ""
+}
+
+// check compares the response of the effe with the expectation,
+// it returns a description of every mismatch found.
+func (e expectation) check(res response) []string {
+	failures := []string{}
+	fail := func(failure string) {
+		if failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+
+	if res.RequestError != "" {
+		fail("invalid request: " + res.RequestError)
+		return failures
+	}
+	if res.Panic != "" {
+		fail("the logic panicked: " + res.Panic)
+	}
+	if e.Status != 0 && e.Status != res.Status {
+		fail(fmt.Sprintf("status: expected %d, got %d", e.Status, res.Status))
+	}
+
+	headers := http.Header(res.Headers)
+	for _, key := range sortedKeys(e.Headers) {
+		if got := headers.Get(key); got != e.Headers[key] {
+			fail(fmt.Sprintf("header %s: expected %q, got %q", key, e.Headers[key], got))
+		}
+	}
+	for _, key := range sortedKeys(e.HeadersRegex) {
+		fail(matchRegex("header "+key, e.HeadersRegex[key], headers.Get(key)))
+	}
+
+	body := res.body()
+	if e.Body != nil && *e.Body != string(body) {
+		fail(fmt.Sprintf("body: expected %q, got %q", *e.Body, body))
+	}
+	if e.BodyContains != "" && !strings.Contains(string(body), e.BodyContains) {
+		fail(fmt.Sprintf("body: %q does not contain %q", body, e.BodyContains))
+	}
+	if e.BodyRegex != "" {
+		fail(matchRegex("body", e.BodyRegex, string(body)))
+	}
+
+	if len(e.JSON) == 0 && len(e.JSONRegex) == 0 {
+		return failures
+	}
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		fail("body is not valid JSON: " + err.Error())
+		return failures
+	}
+	for _, path := range sortedKeys(e.JSON) {
+		got, err := jsonPath(document, path)
+		if err != nil {
+			fail(err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(got, e.JSON[path]) {
+			expected, _ := json.Marshal(e.JSON[path])
+			actual, _ := json.Marshal(got)
+			fail(fmt.Sprintf("%s: expected %s, got %s", path, expected, actual))
+		}
+	}
+	for _, path := range sortedKeys(e.JSONRegex) {
+		got, err := jsonPath(document, path)
+		if err != nil {
+			fail(err.Error())
+			continue
+		}
+		value, ok := got.(string)
+		if !ok {
+			encoded, _ := json.Marshal(got)
+			value = string(encoded)
+		}
+		fail(matchRegex(path, e.JSONRegex[path], value))
+	}
+	return failures
+}
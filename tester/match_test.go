@@ -0,0 +1,139 @@
+package tester
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/siscia/effe-tool/commons"
+	"reflect"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	ok := response{Response: commons.Response{
+		Status: 200,
+		Headers: map[string][]string{
+			"Content-Type": {"application/json"},
+			"X-Request-Id": {"1f2e"},
+		},
+		Body: `{"greeting": "Hello effe", "languages": ["en", "it"], "count": 2, "time": "2016-05-01"}`,
+	}}
+
+	cases := []struct {
+		name   string
+		expect string
+		res    response
+		want   []string
+	}{
+		{name: "nothing", expect: `{}`, res: ok},
+		{
+			name:   "everything",
+			expect: `{"status": 200, "headers": {"content-type": "application/json"}, "headersRegex": {"X-Request-Id": "^[0-9a-f]+$"}, "bodyContains": "Hello", "bodyRegex": "effe", "json": {"$.greeting": "Hello effe", "$.languages[1]": "it", "$.count": 2}, "jsonRegex": {"$.time": "^2016-", "$.languages": "\"en\""}}`,
+			res:    ok,
+		},
+		{
+			name:   "status",
+			expect: `{"status": 404}`,
+			res:    ok,
+			want:   []string{"status: expected 404, got 200"},
+		},
+		{
+			name:   "header",
+			expect: `{"headers": {"Content-Type": "text/plain", "X-Missing": "yes"}}`,
+			res:    ok,
+			want: []string{
+				`header Content-Type: expected "text/plain", got "application/json"`,
+				`header X-Missing: expected "yes", got ""`,
+			},
+		},
+		{
+			name:   "header regex",
+			expect: `{"headersRegex": {"X-Request-Id": "^[0-9]+$"}}`,
+			res:    ok,
+			want:   []string{`header X-Request-Id: "1f2e" does not match ^[0-9]+$`},
+		},
+		{
+			name:   "invalid regex",
+			expect: `{"bodyRegex": "("}`,
+			res:    ok,
+			want:   []string{"body: invalid regex (: error parsing regexp: missing closing ): `(`"},
+		},
+		{
+			name:   "body",
+			expect: `{"body": "Hello"}`,
+			res:    response{Response: commons.Response{Status: 200, Body: "Hello effe"}},
+			want:   []string{`body: expected "Hello", got "Hello effe"`},
+		},
+		{
+			name:   "empty body",
+			expect: `{"body": ""}`,
+			res:    response{Response: commons.Response{Status: 200}},
+		},
+		{
+			name:   "base64 body",
+			expect: `{"body": "\u0000binary"}`,
+			res:    response{Response: commons.Response{Status: 200, Body: base64.StdEncoding.EncodeToString([]byte("\x00binary")), IsBase64Encoded: true}},
+		},
+		{
+			name:   "body contains",
+			expect: `{"bodyContains": "Goodbye"}`,
+			res:    response{Response: commons.Response{Status: 200, Body: "Hello effe"}},
+			want:   []string{`body: "Hello effe" does not contain "Goodbye"`},
+		},
+		{
+			name:   "body regex",
+			expect: `{"bodyRegex": "^effe"}`,
+			res:    response{Response: commons.Response{Status: 200, Body: "Hello effe"}},
+			want:   []string{`body: "Hello effe" does not match ^effe`},
+		},
+		{
+			name:   "json",
+			expect: `{"json": {"$.greeting": "Hello", "$.languages": ["en"], "$.missing": 1}}`,
+			res:    ok,
+			want: []string{
+				`$.greeting: expected "Hello", got "Hello effe"`,
+				`$.languages: expected ["en"], got ["en","it"]`,
+				"No key `missing` at $.missing",
+			},
+		},
+		{
+			name:   "json regex",
+			expect: `{"jsonRegex": {"$.count": "^3$", "$.time": "^2017-"}}`,
+			res:    ok,
+			want: []string{
+				`$.count: "2" does not match ^3$`,
+				`$.time: "2016-05-01" does not match ^2017-`,
+			},
+		},
+		{
+			name:   "not json",
+			expect: `{"json": {"$.greeting": "Hello effe"}}`,
+			res:    response{Response: commons.Response{Status: 200, Body: "Hello effe"}},
+			want:   []string{"body is not valid JSON: invalid character 'H' looking for beginning of value"},
+		},
+		{
+			name:   "panic",
+			expect: `{"status": 200}`,
+			res:    response{Response: commons.Response{Status: 500}, Panic: "runtime error"},
+			want:   []string{"the logic panicked: runtime error", "status: expected 200, got 500"},
+		},
+		{
+			name:   "invalid request",
+			expect: `{"status": 200}`,
+			res:    response{RequestError: `parse "health": invalid URI for request`},
+			want:   []string{`invalid request: parse "health": invalid URI for request`},
+		},
+	}
+	for _, c := range cases {
+		var e expectation
+		if err := json.Unmarshal([]byte(c.expect), &e); err != nil {
+			t.Fatalf("%s: %s", c.name, err)
+		}
+		got := e.check(c.res)
+		if c.want == nil {
+			c.want = []string{}
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: failures %q, want %q", c.name, got, c.want)
+		}
+	}
+}
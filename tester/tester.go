@@ -0,0 +1,342 @@
+package tester
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/codegangsta/cli"
+	"github.com/siscia/effe-tool/builder"
+	"github.com/siscia/effe-tool/commons"
+	"github.com/siscia/effe-tool/sources"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// response is what the test harness reports for each request, the
+// harness declares the same struct with commons.GoStruct.
+type response struct {
+	commons.Response
+	// RequestError is set when the request of the fixture is invalid,
+	// the effe is not run
+	RequestError string        `json:"requestError,omitempty"`
+	StartError   string        `json:"startError,omitempty"`
+	RunError     string        `json:"runError,omitempty"`
+	Panic        string        `json:"panic,omitempty"`
+	Duration     time.Duration `json:"duration"`
+}
+
+func (r response) body() []byte {
+	body, _ := r.DecodedBody()
+	return body
+}
+
+// harness returns the source of the test harness, with the
+// envelopes of the requests and of the responses.
+func harness() (string, error) {
+	t, err := template.New("harness").Parse(sources.Harness)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	err = t.Execute(&out, map[string]string{
+		"Request":  commons.GoStruct("request", commons.Request{}),
+		"Response": commons.GoStruct("response", response{}),
+	})
+	return out.String(), err
+}
+
+// fixture is a single test case, read from a JSON file.
+type fixture struct {
+	Name    string          `json:"name"`
+	Request commons.Request `json:"request"`
+	Expect  expectation     `json:"expect"`
+}
+
+type result struct {
+	name     string
+	failures []string
+	duration time.Duration
+}
+
+// suite collects the results of every fixture of a single effe.
+type suite struct {
+	source  string
+	results []result
+	err     error
+}
+
+func (s suite) failed() int {
+	failed := 0
+	for _, r := range s.results {
+		if len(r.failures) > 0 {
+			failed++
+		}
+	}
+	return failed
+}
+
+// loadFixtures reads every `.json` file inside `dir`, the name of
+// the fixture default to the name of the file.
+func loadFixtures(dir string) ([]fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	fixtures := []fixture{}
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var f fixture
+		if err := json.Unmarshal(content, &f); err != nil {
+			return nil, errors.New("Invalid fixture " + path + ": " + err.Error())
+		}
+		if f.Name == "" {
+			f.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures, nil
+}
+
+// runFixtures compiles the effe with the test harness as main,
+// sends all the requests of the fixtures to the harness and checks
+// the responses.
+func runFixtures(sourcePath string, fixtures []fixture, opts builder.Options) ([]result, error) {
+	core, err := harness()
+	if err != nil {
+		return nil, err
+	}
+	execPath, err := builder.CompileWithCore(sourcePath, core, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer commons.CloseWorkspace(filepath.Dir(execPath), false)
+
+	requests := make([]commons.Request, 0, len(fixtures))
+	for _, f := range fixtures {
+		requests = append(requests, f.Request)
+	}
+	input, err := json.Marshal(requests)
+	if err != nil {
+		return nil, err
+	}
+	var output bytes.Buffer
+	cmd := exec.Command(execPath)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &output
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	var responses []response
+	if err := json.Unmarshal(output.Bytes(), &responses); err != nil {
+		return nil, errors.New("Impossible to read the responses of the harness: " + err.Error())
+	}
+	if len(responses) != len(fixtures) {
+		return nil, errors.New("The harness did not answer to every fixture.")
+	}
+
+	results := make([]result, 0, len(fixtures))
+	for i, f := range fixtures {
+		results = append(results, result{
+			name:     f.Name,
+			failures: f.Expect.check(responses[i]),
+			duration: responses[i].Duration,
+		})
+	}
+	return results, nil
+}
+
+// fixturesDir returns the directory of the fixtures of the effe at
+// `sourcePath`: the one passed with --fixtures, otherwise the one of
+// its project, otherwise the default of --fixtures.
+func fixturesDir(sourcePath string, c *cli.Context) string {
+	if !c.IsSet("fixtures") {
+		if dirs, err := commons.FindProjectDirs(sourcePath); err == nil && dirs.Fixtures != "" {
+			return dirs.Fixtures
+		}
+	}
+	return c.String("fixtures")
+}
+
+// testFile runs the fixtures inside `<fixtures>/<name>/`, where name
+// is the name of the source file without extension.
+func testFile(sourcePath string, c *cli.Context) suite {
+	s := suite{source: sourcePath}
+	opts, err := builder.LoadOptions(sourcePath, c)
+	if err != nil {
+		s.err = err
+		return s
+	}
+	name := strings.TrimSuffix(filepath.Base(sourcePath), filepath.Ext(sourcePath))
+	dir := filepath.Join(fixturesDir(sourcePath, c), name)
+	fixtures, err := loadFixtures(dir)
+	if err != nil {
+		s.err = err
+		return s
+	}
+	if len(fixtures) == 0 {
+		s.err = errors.New("No fixture found in " + dir)
+		return s
+	}
+	s.results, s.err = runFixtures(sourcePath, fixtures, opts)
+	return s
+}
+
+func reportHuman(w io.Writer, suites []suite) {
+	passed, failed := 0, 0
+	for _, s := range suites {
+		if s.err != nil {
+			fmt.Fprintln(w, "File: "+s.source+" | ERROR "+s.err.Error())
+			failed++
+			continue
+		}
+		for _, r := range s.results {
+			if len(r.failures) == 0 {
+				fmt.Fprintln(w, "File: "+s.source+" | PASS "+r.name)
+				passed++
+				continue
+			}
+			fmt.Fprintln(w, "File: "+s.source+" | FAIL "+r.name)
+			for _, failure := range r.failures {
+				fmt.Fprintln(w, "\t"+failure)
+			}
+			failed++
+		}
+	}
+	fmt.Fprintf(w, "\n%d passed, %d failed\n", passed, failed)
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+	Error     *junitFailure   `xml:"error,omitempty"`
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.6f", d.Seconds())
+}
+
+func reportJUnit(w io.Writer, suites []suite) error {
+	report := junitTestSuites{}
+	for _, s := range suites {
+		js := junitTestSuite{Name: s.source, Tests: len(s.results), Failures: s.failed()}
+		if s.err != nil {
+			js.Errors = 1
+			js.Error = &junitFailure{Message: s.err.Error()}
+		}
+		var total time.Duration
+		for _, r := range s.results {
+			tc := junitTestCase{Name: r.name, ClassName: s.source, Time: seconds(r.duration)}
+			if len(r.failures) > 0 {
+				tc.Failure = &junitFailure{Message: r.failures[0], Text: strings.Join(r.failures, "\n")}
+			}
+			total += r.duration
+			js.TestCases = append(js.TestCases, tc)
+		}
+		js.Time = seconds(total)
+		report.Suites = append(report.Suites, js)
+	}
+	io.WriteString(w, xml.Header)
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// writeReport writes the report in the given format on `output`,
+// or on the standard output if `output` is empty.
+func writeReport(output, format string, suites []suite) error {
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	if format == "junit" {
+		return reportJUnit(w, suites)
+	}
+	reportHuman(w, suites)
+	return nil
+}
+
+// Test is the main entry point, it runs the fixtures of a single
+// effe or of every effe inside the directory passed as argument.
+// The exit status is not zero if any fixture fails.
+func Test(c *cli.Context) {
+	path := builder.SourcesPath(c)
+	f, err := os.Lstat(path)
+	if err != nil {
+		fmt.Println("Impossible to open the file, are you sure it exist ?")
+		return
+	}
+
+	suites := []suite{}
+	if f.IsDir() {
+		ignore := commons.LoadIgnore(path)
+		filepath.Walk(path, func(path string, f os.FileInfo, _ error) error {
+			if ignore.Match(path) {
+				if f.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if f.Mode().IsRegular() && filepath.Ext(path) == ".go" {
+				suites = append(suites, testFile(path, c))
+			}
+			return nil
+		})
+	}
+	if f.Mode().IsRegular() {
+		suites = append(suites, testFile(path, c))
+	}
+
+	if err := writeReport(c.String("output"), c.String("format"), suites); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	for _, s := range suites {
+		if s.err != nil || s.failed() > 0 {
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package tester
+
+import (
+	"github.com/siscia/effe-tool/builder"
+	"github.com/siscia/effe-tool/commons"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const echoEffe = `package logic
+
+import (
+	"io"
+	"net/http"
+)
+
+var Info = ` + "`" + `{"name": "echo", "version": "1.0"}` + "`" + `
+
+type Context struct{}
+
+func Init() {}
+
+func Start() (Context, error) { return Context{}, nil }
+
+func Run(ctx Context, err error, w http.ResponseWriter, r *http.Request) error {
+	io.WriteString(w, r.Method+" "+r.URL.Path)
+	return nil
+}
+
+func Stop(ctx Context) {}
+`
+
+// TestRunFixtures runs the fixtures through the harness, a fixture
+// with an invalid request fails alone.
+func TestRunFixtures(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go tool is needed to compile the harness")
+	}
+	source := filepath.Join(t.TempDir(), "echo.go")
+	if err := ioutil.WriteFile(source, []byte(echoEffe), 0644); err != nil {
+		t.Fatal(err)
+	}
+	body := func(s string) *string {
+		return &s
+	}
+	fixtures := []fixture{
+		{Name: "root", Request: commons.Request{Path: "/"}, Expect: expectation{Status: 200, Body: body("GET /")}},
+		{Name: "no slash", Request: commons.Request{Path: "health"}, Expect: expectation{Status: 200}},
+		{Name: "bad method", Request: commons.Request{Method: "GET /", Path: "/"}, Expect: expectation{Status: 200}},
+		{Name: "post", Request: commons.Request{Method: "POST", Path: "/users?id=1"}, Expect: expectation{Body: body("POST /users")}},
+	}
+	results, err := runFixtures(source, fixtures, builder.Options{Profile: builder.DefaultProfile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := map[string][]string{}
+	for _, r := range results {
+		if len(r.failures) > 0 {
+			failed[r.name] = r.failures
+		}
+	}
+	if len(failed) != 2 {
+		t.Fatalf("failures %q, want only the invalid requests", failed)
+	}
+	for _, name := range []string{"no slash", "bad method"} {
+		if len(failed[name]) != 1 || !strings.HasPrefix(failed[name][0], "invalid request: ") {
+			t.Errorf("%s: failures %q", name, failed[name])
+		}
+	}
+}